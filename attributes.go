@@ -0,0 +1,188 @@
+package menu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+var (
+	_ json.Marshaler   = (*Attributes)(nil)
+	_ json.Unmarshaler = (*Attributes)(nil)
+)
+
+// Attributes is an ordered set of HTML attributes: unlike the attribute maps of Item, it keeps the names in
+// insertion order, so attributes can be manipulated and rendered in a reproducible, author-chosen order.
+// It marshals to and from a JSON object keeping the order of its keys. The zero value is empty and ready to use.
+//
+// The renderers accept it wherever the templates render attributes, see renderer.FuncMap; Map converts it
+// to the map fields of Item, which are rendered sorted by name.
+//
+// Example usage:
+//
+//	attrs := menu.NewAttributes("id", "account", "class", "nav-item")
+//	attrs.Set("data-turbo", false).Delete("id")
+type Attributes struct {
+	names  []string
+	values map[string]any
+}
+
+// NewAttributes creates new Attributes from name and value pairs, e.g. NewAttributes("id", "blog", "class", "active").
+// A trailing name without value is ignored.
+func NewAttributes(pairs ...any) *Attributes {
+	a := &Attributes{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		a.Set(fmt.Sprint(pairs[i]), pairs[i+1])
+	}
+	return a
+}
+
+// AttributesFromMap creates new Attributes holding the entries of the map, sorted by name.
+func AttributesFromMap(m map[string]any) *Attributes {
+	a := &Attributes{}
+	for _, name := range slices.Sorted(maps.Keys(m)) {
+		a.Set(name, m[name])
+	}
+	return a
+}
+
+// Len returns the number of attributes.
+func (a *Attributes) Len() int {
+	return len(a.names)
+}
+
+// Names returns the names of the attributes in insertion order.
+func (a *Attributes) Names() []string {
+	return slices.Clone(a.names)
+}
+
+// Get returns the value of the attribute and whether it is set.
+func (a *Attributes) Get(name string) (any, bool) {
+	value, ok := a.values[name]
+	return value, ok
+}
+
+// Has checks if the attribute is set.
+func (a *Attributes) Has(name string) bool {
+	_, ok := a.values[name]
+	return ok
+}
+
+// Set sets the value of the attribute, keeping its position if it is already set, appending it otherwise.
+// It returns the attributes.
+func (a *Attributes) Set(name string, value any) *Attributes {
+	if a.values == nil {
+		a.values = map[string]any{}
+	}
+	if _, ok := a.values[name]; !ok {
+		a.names = append(a.names, name)
+	}
+	a.values[name] = value
+	return a
+}
+
+// Delete removes the attributes with the given names and returns the attributes.
+func (a *Attributes) Delete(names ...string) *Attributes {
+	for _, name := range names {
+		if _, ok := a.values[name]; !ok {
+			continue
+		}
+		delete(a.values, name)
+		a.names = slices.DeleteFunc(a.names, func(n string) bool { return n == name })
+	}
+	return a
+}
+
+// Merge sets the attributes of the others in order, see Set: the values of the others win and their new names
+// are appended. It returns the attributes.
+func (a *Attributes) Merge(others ...*Attributes) *Attributes {
+	for _, other := range others {
+		if other == nil {
+			continue
+		}
+		for name, value := range other.All() {
+			a.Set(name, value)
+		}
+	}
+	return a
+}
+
+// All returns an iterator over the names and values of the attributes in insertion order.
+func (a *Attributes) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for _, name := range a.names {
+			if !yield(name, a.values[name]) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the attributes. The values are not copied.
+func (a *Attributes) Clone() *Attributes {
+	return &Attributes{names: slices.Clone(a.names), values: maps.Clone(a.values)}
+}
+
+// Map returns the attributes as a map, e.g. for the attribute fields of Item, or nil without attributes.
+func (a *Attributes) Map() map[string]any {
+	return maps.Clone(a.values)
+}
+
+// MarshalJSON encodes the attributes as a JSON object with the keys in insertion order.
+func (a *Attributes) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, name := range a.names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(a.values[name])
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the attributes, keeping the order of its keys.
+// The attributes set before are replaced.
+func (a *Attributes) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("attributes: expected a JSON object, got %v", tok)
+	}
+
+	*a = Attributes{}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+
+		var value any
+		if err = dec.Decode(&value); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+		a.Set(name, value)
+	}
+
+	_, err = dec.Token()
+	return err
+}
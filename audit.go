@@ -0,0 +1,208 @@
+package menu
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// LinkStatus is the outcome of the check of the URI of an item by Audit.
+type LinkStatus string
+
+const (
+	// LinkOK reports a reachable external URI or an internal URI served by the application.
+	LinkOK LinkStatus = "ok"
+	// LinkBroken reports an external URI answering with an error status or an internal URI missing from the routes.
+	LinkBroken LinkStatus = "broken"
+	// LinkUnreachable reports an external URI that could not be requested, e.g. an unknown host or a timeout.
+	LinkUnreachable LinkStatus = "unreachable"
+	// LinkSkipped reports a URI that is not checked: an internal URI without route table, or a URI
+	// with another scheme than http and https, e.g. mailto:.
+	LinkSkipped LinkStatus = "skipped"
+)
+
+// RouteTable holds the paths served by the application as URI patterns, see MatchURIPattern,
+// e.g. {"/", "/blog", "/blog/*"}.
+type RouteTable []string
+
+// Has checks if the path matches one of the patterns of the table.
+func (t RouteTable) Has(path string) bool {
+	for _, pattern := range t {
+		if MatchURIPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// LinkCheck is the check of the URI of an item, see Audit.
+type LinkCheck struct {
+	// Key is the key of the item, see Item.Key.
+	Key string `json:"key"`
+	// Path is the path of the item from the root, its names separated by "/".
+	Path string `json:"path"`
+	URI  string `json:"uri"`
+	// External reports whether the URI is absolute, checked with a request, or internal, checked against the routes.
+	External bool       `json:"external"`
+	Status   LinkStatus `json:"status"`
+	// StatusCode is the HTTP status of the response to the request checking an external URI.
+	StatusCode int `json:"status_code,omitempty"`
+	// Error describes why the URI is broken or unreachable.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditReport is the report of Audit, for admin APIs and lint commands.
+type AuditReport struct {
+	// Links holds the checks of the items with a URI, in depth-first order.
+	Links []LinkCheck `json:"links"`
+	// Broken is the number of broken and unreachable links.
+	Broken int `json:"broken"`
+}
+
+// OK reports whether no link is broken nor unreachable.
+func (r AuditReport) OK() bool {
+	return r.Broken == 0
+}
+
+// AuditOption customizes the checks made by Audit.
+type AuditOption func(*auditOptions)
+
+type auditOptions struct {
+	routes      RouteTable
+	concurrency int
+}
+
+// AuditRoutes is an AuditOption checking the internal URIs, the URIs without scheme nor host, against the routes.
+// Without routes, the internal URIs are skipped.
+func AuditRoutes(routes RouteTable) AuditOption {
+	return func(o *auditOptions) {
+		o.routes = routes
+	}
+}
+
+// AuditConcurrency is an AuditOption setting the number of external URIs checked at the same time, 8 by default.
+func AuditConcurrency(n int) AuditOption {
+	return func(o *auditOptions) {
+		o.concurrency = n
+	}
+}
+
+// Audit checks the URIs of the item and its descendants for broken links: the external URIs are requested
+// concurrently with HEAD requests, falling back to GET for the servers not supporting HEAD, and answering
+// with a 4xx or 5xx status are broken; the internal URIs are looked up in the routes, see AuditRoutes.
+// A URI used by several items is checked once. The http.DefaultClient is used when the client is nil,
+// set its Timeout to bound the duration of the audit.
+//
+// The returned error is the error of the context if it is done before the audit completes.
+//
+// Example usage:
+//
+//	report, err := menu.Audit(ctx, root, &http.Client{Timeout: 5 * time.Second},
+//		menu.AuditRoutes(menu.RouteTable{"/", "/blog", "/blog/*"}),
+//	)
+func Audit(ctx context.Context, root *Item, client *http.Client, options ...AuditOption) (AuditReport, error) {
+	o := auditOptions{concurrency: 8}
+	for _, option := range options {
+		option(&o)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	report := AuditReport{Links: []LinkCheck{}}
+	external := map[string][]int{}
+	for item := range root.All() {
+		if item.URI == "" {
+			continue
+		}
+
+		check := LinkCheck{Key: item.Key(), Path: item.PathString("/"), URI: item.URI}
+		switch u, err := url.Parse(item.URI); {
+		case err != nil:
+			check.Status, check.Error = LinkBroken, err.Error()
+		case u.Scheme == "http" || u.Scheme == "https":
+			check.External = true
+			external[item.URI] = append(external[item.URI], len(report.Links))
+		case u.Scheme != "" || u.Host != "" || o.routes == nil:
+			check.Status = LinkSkipped
+		case o.routes.Has(internalPath(u)):
+			check.Status = LinkOK
+		default:
+			check.Status, check.Error = LinkBroken, "no route"
+		}
+		report.Links = append(report.Links, check)
+	}
+
+	var mu sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(o.concurrency, 1))
+	for uri, indexes := range external {
+		g.Go(func() error {
+			status, code, msg := checkLink(gctx, client, uri)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, i := range indexes {
+				report.Links[i].Status, report.Links[i].StatusCode, report.Links[i].Error = status, code, msg
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return report, err
+	}
+
+	for _, check := range report.Links {
+		if check.Status == LinkBroken || check.Status == LinkUnreachable {
+			report.Broken++
+		}
+	}
+	return report, nil
+}
+
+// internalPath returns the path of an internal URI, "/" for the URIs made of a query or a fragment only.
+func internalPath(u *url.URL) string {
+	if u.Path == "" {
+		return "/"
+	}
+	if !strings.HasPrefix(u.Path, "/") {
+		return "/" + u.Path
+	}
+	return u.Path
+}
+
+// checkLink requests the URI with HEAD, then with GET if the server does not support HEAD.
+func checkLink(ctx context.Context, client *http.Client, uri string) (LinkStatus, int, string) {
+	code, err := requestLink(ctx, client, http.MethodHead, uri)
+	if err == nil && (code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented) {
+		code, err = requestLink(ctx, client, http.MethodGet, uri)
+	}
+
+	switch {
+	case err != nil:
+		return LinkUnreachable, 0, err.Error()
+	case code >= http.StatusBadRequest:
+		return LinkBroken, code, http.StatusText(code)
+	}
+	return LinkOK, code, ""
+}
+
+func requestLink(ctx context.Context, client *http.Client, method, uri string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,307 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var _ Provider = (*CachedProvider)(nil)
+
+// WarmError reports a menu that could not be built by CachedProvider.Warm.
+type WarmError struct {
+	Name string
+	Err  error
+}
+
+func (e *WarmError) Error() string {
+	return fmt.Sprintf("warm menu %q: %v", e.Name, e.Err)
+}
+
+func (e *WarmError) Unwrap() error {
+	return e.Err
+}
+
+// ProviderStats describes the state of a CachedProvider for health endpoints and dashboards.
+type ProviderStats struct {
+	// Menus holds the statistics of the menus known to the provider, keyed by name.
+	Menus map[string]MenuStats `json:"menus"`
+	// Hits is the number of Get calls served from the cache. The loads of Warm are not counted.
+	Hits uint64 `json:"hits"`
+	// Misses is the number of Get calls that had to build the menu.
+	Misses uint64 `json:"misses"`
+	// HitRatio is Hits divided by the total number of Get calls, 0 without calls.
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// MenuStats describes the builds of a menu by a CachedProvider.
+type MenuStats struct {
+	// Cached reports whether the menu is currently cached.
+	Cached bool `json:"cached"`
+	// Stale reports whether the last rebuild of the expired menu failed, so the cached menu is still served.
+	Stale bool `json:"stale"`
+	// Builds is the number of successful builds.
+	Builds int `json:"builds"`
+	// Errors is the number of failed builds.
+	Errors int `json:"errors"`
+	// LastBuild is the time of the last successful build.
+	LastBuild time.Time `json:"last_build"`
+	// LastBuildDuration is the duration of the last successful build.
+	LastBuildDuration time.Duration `json:"last_build_duration"`
+	// LastError is the error of the last failed build, empty if the last build succeeded.
+	LastError string `json:"last_error,omitempty"`
+	// LastErrorAt is the time of the last failed build.
+	LastErrorAt time.Time `json:"last_error_at"`
+}
+
+type cacheEntry struct {
+	item    *Item
+	expires time.Time
+	// stale is set when a rebuild of the expired menu failed.
+	stale bool
+}
+
+func (e cacheEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// CachedProvider is a Provider caching the menus built by another provider.
+// Concurrent requests for a menu that is not cached yet share a single build.
+//
+// By default, the menus are cached until they are invalidated, see SetTTL to rebuild them periodically.
+// When an expired menu cannot be rebuilt, the stale menu keeps being served and the error is reported by Stats.
+//
+// The cached menus are shared between callers: they must be treated as read-only, use Item.Copy
+// to get a tree that can be modified.
+type CachedProvider struct {
+	provider Provider
+	group    singleflight.Group
+	mu       sync.RWMutex
+	ttl      time.Duration
+	menus    map[string]cacheEntry
+	gens     map[string]uint64
+	epoch    uint64
+	building map[string]int
+	stats    map[string]MenuStats
+	hits     uint64
+	misses   uint64
+}
+
+// NewCachedProvider creates a new CachedProvider caching the menus of the given provider.
+func NewCachedProvider(provider Provider) *CachedProvider {
+	return &CachedProvider{
+		provider: provider,
+		menus:    map[string]cacheEntry{},
+		gens:     map[string]uint64{},
+		building: map[string]int{},
+		stats:    map[string]MenuStats{},
+	}
+}
+
+// SetTTL sets how long the menus are cached, they never expire with a zero TTL, the default.
+// It applies to the menus built afterwards.
+func (p *CachedProvider) SetTTL(ttl time.Duration) *CachedProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ttl = ttl
+	return p
+}
+
+// Invalidate removes the menus with the given names from the cache, they are rebuilt on the next call to Get.
+// The builds of the menus in progress are not cached, as they may have read the data before the change.
+func (p *CachedProvider) Invalidate(names ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, name := range names {
+		delete(p.menus, name)
+		p.gens[name]++
+		p.group.Forget(name)
+	}
+}
+
+// InvalidateAll removes all the menus from the cache, the builds in progress included, see Invalidate.
+func (p *CachedProvider) InvalidateAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for name := range p.menus {
+		p.group.Forget(name)
+	}
+	for name := range p.building {
+		p.group.Forget(name)
+	}
+	clear(p.menus)
+	p.epoch++
+}
+
+// generation returns the generation of the menu with the given name, increased by Invalidate and InvalidateAll.
+// The caller must hold the lock.
+func (p *CachedProvider) generation(name string) uint64 {
+	return p.epoch + p.gens[name]
+}
+
+// Get returns the cached menu with the given name, building it with the underlying provider on the first call
+// and once it expired. Errors are not cached.
+//
+// When an expired menu cannot be rebuilt, the stale menu is returned without error, so a failing source does not
+// break the pages using it: the error is only reported by Stats and Warm.
+func (p *CachedProvider) Get(ctx context.Context, name string) (*Item, error) {
+	item, err := p.load(ctx, name, true)
+	if item != nil {
+		return item, nil
+	}
+	return nil, err
+}
+
+// load returns the cached menu with the given name, building it when it is missing or expired.
+// When the build fails, the error is returned with the stale menu, if any.
+// The hits and misses are only counted when count is set, i.e. not for Warm.
+func (p *CachedProvider) load(ctx context.Context, name string, count bool) (*Item, error) {
+	p.mu.Lock()
+	entry, ok := p.menus[name]
+	fresh := ok && !entry.expired(time.Now())
+	switch {
+	case !count:
+	case fresh:
+		p.hits++
+	default:
+		p.misses++
+	}
+	p.mu.Unlock()
+	if fresh {
+		return entry.item, nil
+	}
+
+	// the build is shared by the concurrent callers, it must not be canceled with the context of the first one
+	ctx = context.WithoutCancel(ctx)
+
+	v, err, _ := p.group.Do(name, func() (any, error) {
+		p.mu.Lock()
+		gen := p.generation(name)
+		p.building[name]++
+		p.mu.Unlock()
+
+		start := time.Now()
+		item, err := p.provider.Get(ctx, name)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if p.building[name]--; p.building[name] == 0 {
+			delete(p.building, name)
+		}
+
+		stats := p.stats[name]
+		if err != nil {
+			stats.Errors++
+			stats.LastError = err.Error()
+			stats.LastErrorAt = time.Now()
+			p.stats[name] = stats
+
+			if stale, ok := p.menus[name]; ok {
+				stale.stale = true
+				p.menus[name] = stale
+				return stale.item, err
+			}
+			return nil, err
+		}
+
+		stats.Builds++
+		stats.LastBuild = start
+		stats.LastBuildDuration = time.Since(start)
+		stats.LastError = ""
+		p.stats[name] = stats
+
+		// the menu was invalidated during the build, which may have read the data before the change
+		if p.generation(name) != gen {
+			return item, nil
+		}
+
+		entry := cacheEntry{item: item}
+		if p.ttl > 0 {
+			entry.expires = start.Add(p.ttl)
+		}
+		p.menus[name] = entry
+
+		return item, nil
+	})
+	item, _ := v.(*Item)
+	return item, err
+}
+
+// Has checks if the underlying provider knows the menu with the given name.
+func (p *CachedProvider) Has(ctx context.Context, name string) bool {
+	p.mu.RLock()
+	_, ok := p.menus[name]
+	p.mu.RUnlock()
+	return ok || p.provider.Has(ctx, name)
+}
+
+// Stats returns a snapshot of the statistics of the provider. The menus listed by the Names method
+// of the underlying provider, if any, are reported even if they were never requested.
+func (p *CachedProvider) Stats() ProviderStats {
+	var names []string
+	if lister, ok := p.provider.(interface{ Names() []string }); ok {
+		names = lister.Names()
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	stats := ProviderStats{
+		Menus:  make(map[string]MenuStats, len(p.stats)),
+		Hits:   p.hits,
+		Misses: p.misses,
+	}
+	if total := p.hits + p.misses; total > 0 {
+		stats.HitRatio = float64(p.hits) / float64(total)
+	}
+
+	for _, name := range names {
+		stats.Menus[name] = MenuStats{}
+	}
+	for name, menu := range p.stats {
+		entry, ok := p.menus[name]
+		menu.Cached = ok
+		menu.Stale = ok && entry.stale
+		stats.Menus[name] = menu
+	}
+
+	return stats
+}
+
+// Warm builds and caches the given menus concurrently, e.g. at startup, so the first request
+// does not pay the cost of building them. Without names, all the menus of the underlying provider are warmed
+// when it lists them with a Names method, as ConfigProvider and Registry do.
+//
+// The menus that could not be built are reported as *WarmError joined in the returned error,
+// even when a stale menu is still cached and served by Get, the others are cached regardless.
+func (p *CachedProvider) Warm(ctx context.Context, names ...string) error {
+	if len(names) == 0 {
+		if lister, ok := p.provider.(interface{ Names() []string }); ok {
+			names = lister.Names()
+		}
+	}
+
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := p.load(ctx, name, false); err != nil {
+				errs[i] = &WarmError{Name: name, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
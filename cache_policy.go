@@ -0,0 +1,87 @@
+package menu
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CacheHintExtra holds the HTTP cache hint of an item, aggregated by CachePolicy.
+var CacheHintExtra = DefineExtra("menu", ExtraKey("menu", "cache"), CacheHint{}, "cache")
+
+// WithCacheHint is a function that returns an Option for setting the "menu.cache" extra of an Item,
+// e.g. WithCacheHint(CacheHint{Private: true}) for an item whose label shows the name of the user.
+func WithCacheHint(hint CacheHint) Option {
+	return CacheHintExtra.Option(hint)
+}
+
+// CacheHint describes how a page rendering an item may be cached by HTTP caches.
+// The zero value puts no constraint on the page.
+type CacheHint struct {
+	// Private reports whether the item is personalized, so the page must not be stored by shared caches.
+	Private bool `json:"private,omitempty"`
+	// NoStore reports whether the page must not be stored by any cache.
+	NoStore bool `json:"no_store,omitempty"`
+	// MaxAge is the number of seconds the item stays fresh, 0 means no limit.
+	MaxAge int `json:"max_age,omitempty"`
+}
+
+// IsZero reports whether the hint puts no constraint on the page.
+func (h CacheHint) IsZero() bool {
+	return h == CacheHint{}
+}
+
+// Merge returns the most restrictive combination of the two hints: a directive set by either hint is kept
+// and the shortest max age wins.
+func (h CacheHint) Merge(other CacheHint) CacheHint {
+	h.Private = h.Private || other.Private
+	h.NoStore = h.NoStore || other.NoStore
+	if other.MaxAge > 0 && (h.MaxAge <= 0 || other.MaxAge < h.MaxAge) {
+		h.MaxAge = other.MaxAge
+	}
+	return h
+}
+
+// String returns the value of the Cache-Control header for the hint, e.g. "private, max-age=60",
+// or an empty string for the zero hint.
+func (h CacheHint) String() string {
+	if h.NoStore {
+		return "no-store"
+	}
+
+	var directives []string
+	if h.Private {
+		directives = append(directives, "private")
+	}
+	if h.MaxAge > 0 {
+		directives = append(directives, "max-age="+strconv.Itoa(h.MaxAge))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// Apply sets the Cache-Control header to the value of the hint, the header is left untouched for the zero hint.
+//
+// Example usage:
+//
+//	menu.CachePolicy(ctx, root).Apply(w.Header())
+func (h CacheHint) Apply(header http.Header) {
+	if value := h.String(); value != "" {
+		header.Set("Cache-Control", value)
+	}
+}
+
+// CachePolicy returns the most restrictive cache hint of the items rendered from the root, so pages embedding
+// personalized menus get a correct Cache-Control header. The root is included, while the items hidden or rejected
+// by the filters, their descendants and the children of the items not displaying them are skipped,
+// as renderers do.
+func CachePolicy(ctx context.Context, root *Item, filters ...Filter) CacheHint {
+	hint := CacheHintExtra.Get(root)
+	if !root.DisplayChildren {
+		return hint
+	}
+	for _, child := range root.VisibleChildren(ctx, filters...) {
+		hint = hint.Merge(CachePolicy(ctx, child, filters...))
+	}
+	return hint
+}
@@ -0,0 +1,169 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingProvider builds a new menu on each call, or fails with err when it is set.
+type countingProvider struct {
+	mu     sync.Mutex
+	builds int
+	err    error
+}
+
+func (p *countingProvider) Get(_ context.Context, name string) (*Item, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.builds++
+	return NewItem(name)
+}
+
+func (p *countingProvider) Has(context.Context, string) bool {
+	return true
+}
+
+func (p *countingProvider) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
+
+func TestCachedProviderWarmIsNotCounted(t *testing.T) {
+	p := NewCachedProvider(&countingProvider{})
+	ctx := context.Background()
+
+	if err := p.Warm(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if stats := p.Stats(); stats.Hits != 0 || stats.Misses != 0 {
+		t.Errorf("Stats() after Warm = %d hits, %d misses, want none", stats.Hits, stats.Misses)
+	}
+
+	if _, err := p.Get(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if stats := p.Stats(); stats.Hits != 1 || stats.Misses != 0 || stats.HitRatio != 1 {
+		t.Errorf("Stats() = %d hits, %d misses, ratio %v, want a single hit", stats.Hits, stats.Misses, stats.HitRatio)
+	}
+}
+
+func TestCachedProviderStale(t *testing.T) {
+	provider := &countingProvider{}
+	p := NewCachedProvider(provider).SetTTL(time.Millisecond)
+	ctx := context.Background()
+
+	first, err := p.Get(ctx, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if p.Stats().Menus["main"].Stale {
+		t.Error("an expired menu not requested again is reported as stale")
+	}
+
+	provider.fail(errors.New("source down"))
+	item, err := p.Get(ctx, "main")
+	if err != nil || item != first {
+		t.Fatalf("Get() = %p, %v, want the stale menu %p without error", item, err, first)
+	}
+	if stats := p.Stats().Menus["main"]; !stats.Stale || stats.Errors != 1 || stats.LastError != "source down" {
+		t.Errorf("Stats() = %+v, want a stale menu with the error", stats)
+	}
+
+	provider.fail(nil)
+	if item, err = p.Get(ctx, "main"); err != nil || item == first {
+		t.Fatalf("Get() = %p, %v, want a rebuilt menu", item, err)
+	}
+	if p.Stats().Menus["main"].Stale {
+		t.Error("a rebuilt menu is reported as stale")
+	}
+}
+
+func TestCachedProviderTTL(t *testing.T) {
+	provider := &countingProvider{}
+	ctx := context.Background()
+
+	p := NewCachedProvider(provider)
+	first, _ := p.Get(ctx, "main")
+	if item, _ := p.Get(ctx, "main"); item != first || provider.builds != 1 {
+		t.Errorf("Get() without TTL rebuilt the menu, %d builds", provider.builds)
+	}
+
+	p = NewCachedProvider(provider).SetTTL(time.Millisecond)
+	first, _ = p.Get(ctx, "main")
+	time.Sleep(2 * time.Millisecond)
+	if item, _ := p.Get(ctx, "main"); item == first {
+		t.Error("Get() served an expired menu without rebuilding it")
+	}
+}
+
+func TestCachedProviderInvalidate(t *testing.T) {
+	provider := &countingProvider{}
+	p := NewCachedProvider(provider)
+	ctx := context.Background()
+
+	main, _ := p.Get(ctx, "main")
+	footer, _ := p.Get(ctx, "footer")
+
+	p.Invalidate("main")
+	if item, _ := p.Get(ctx, "main"); item == main {
+		t.Error("Get() served an invalidated menu")
+	}
+	if item, _ := p.Get(ctx, "footer"); item != footer {
+		t.Error("Invalidate(main) dropped another menu")
+	}
+
+	p.InvalidateAll()
+	if item, _ := p.Get(ctx, "footer"); item == footer {
+		t.Error("Get() served a menu after InvalidateAll")
+	}
+	if provider.builds != 4 {
+		t.Errorf("the provider built %d menus, want 4", provider.builds)
+	}
+}
+
+// blockingProvider builds a new menu on each call once released.
+type blockingProvider struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p blockingProvider) Get(_ context.Context, name string) (*Item, error) {
+	p.started <- struct{}{}
+	<-p.release
+	return NewItem(name)
+}
+
+func (p blockingProvider) Has(context.Context, string) bool {
+	return true
+}
+
+func TestCachedProviderInvalidateDuringBuild(t *testing.T) {
+	provider := blockingProvider{started: make(chan struct{}), release: make(chan struct{})}
+	p := NewCachedProvider(provider)
+	ctx := context.Background()
+
+	built := make(chan *Item)
+	go func() {
+		item, _ := p.Get(ctx, "main")
+		built <- item
+	}()
+	<-provider.started
+	p.Invalidate("main")
+	close(provider.release)
+	outdated := <-built
+
+	go func() { <-provider.started }()
+	if item, _ := p.Get(ctx, "main"); item == outdated {
+		t.Error("Get() served a menu built before Invalidate")
+	}
+}
@@ -0,0 +1,57 @@
+package menu
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+)
+
+var _ Loader = ChainLoader{}
+
+// ChainLoader is a Loader dispatching the data to the first of its loaders supporting it,
+// so JSON documents, nodes and database rows can be loaded behind a single entry point.
+type ChainLoader struct {
+	loaders []Loader
+}
+
+// NewChainLoader creates a new ChainLoader trying the given loaders in order.
+func NewChainLoader(loaders ...Loader) ChainLoader {
+	return ChainLoader{loaders: slices.Clone(loaders)}
+}
+
+// Append returns a copy of the loader also trying the given loaders, after the current ones.
+func (l ChainLoader) Append(loaders ...Loader) ChainLoader {
+	l.loaders = append(slices.Clip(l.loaders), loaders...)
+	return l
+}
+
+// Load loads the data with the first loader supporting it.
+// When no loader supports an io.Reader, the loaders are tried again with the reader wrapped in a *bufio.Reader,
+// whose beginning they can inspect without consuming it, e.g. JSONLoader checks that it holds a JSON object.
+// An error wrapping ErrUnsupported is returned when no loader supports the data.
+func (l ChainLoader) Load(ctx context.Context, data any) (*Item, error) {
+	for _, loader := range l.loaders {
+		if loader.Supports(data) {
+			return loader.Load(ctx, data)
+		}
+	}
+
+	if r, ok := data.(io.Reader); ok {
+		if _, ok = r.(*bufio.Reader); !ok {
+			return l.Load(ctx, bufio.NewReader(r))
+		}
+	}
+	return nil, fmt.Errorf("%w: no loader supports %T", ErrUnsupported, data)
+}
+
+// Supports checks if at least one of the loaders supports the given data.
+func (l ChainLoader) Supports(data any) bool {
+	for _, loader := range l.loaders {
+		if loader.Supports(data) {
+			return true
+		}
+	}
+	return false
+}
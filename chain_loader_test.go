@@ -0,0 +1,25 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChainLoaderReader(t *testing.T) {
+	loader := NewChainLoader(NewJSONLoader())
+
+	root, err := loader.Load(context.Background(), strings.NewReader(` {"name":"root","children":[{"name":"home","uri":"/"}]}`))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if root.Name != "root" || root.Child("home") == nil {
+		t.Errorf("Load() = %q with children %d, want root with home", root.Name, len(root.Children))
+	}
+
+	_, err = loader.Load(context.Background(), strings.NewReader("name: root"))
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Load() error = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,101 @@
+package menu
+
+import "context"
+
+// Built-in render channels. A channel names the placement a menu is rendered in, so a single tree can show
+// an item in the site map but not in the navbar, or in the breadcrumbs but not in the menu.
+// Channels are plain strings, applications are free to define their own zones.
+const (
+	ChannelMenu        = "menu"
+	ChannelNavbar      = "navbar"
+	ChannelFooter      = "footer"
+	ChannelBreadcrumbs = "breadcrumbs"
+	ChannelSitemap     = "sitemap"
+)
+
+type channelKey struct{}
+
+// ContextWithChannel returns a copy of the context carrying the render channel.
+// Renderers attach their channel to the context, so filters and decorators can adapt to the placement.
+func ContextWithChannel(ctx context.Context, channel string) context.Context {
+	return context.WithValue(ctx, channelKey{}, channel)
+}
+
+// ChannelFromContext returns the render channel carried by the context, or an empty string.
+func ChannelFromContext(ctx context.Context) string {
+	channel, _ := ctx.Value(channelKey{}).(string)
+	return channel
+}
+
+// ChannelsExtra holds the visibility of an item per channel. Items are visible in the channels they don't list.
+var ChannelsExtra = DefineExtra("menu", ExtraKey("menu", "channels"), map[string]bool(nil), "channels")
+
+// WithVisibleIn is a function that returns an Option for showing or hiding an Item in the given channel.
+func WithVisibleIn(channel string, visible bool) Option {
+	return func(item *Item) error {
+		channels := map[string]bool{}
+		for name, value := range item.channels() {
+			channels[name] = value
+		}
+		channels[channel] = visible
+		ChannelsExtra.Set(item, channels)
+		return nil
+	}
+}
+
+// WithVisibleInMenu is a function that returns an Option for showing or hiding an Item in menus.
+func WithVisibleInMenu(visible bool) Option {
+	return WithVisibleIn(ChannelMenu, visible)
+}
+
+// WithVisibleInBreadcrumbs is a function that returns an Option for showing or hiding an Item in breadcrumbs.
+func WithVisibleInBreadcrumbs(visible bool) Option {
+	return WithVisibleIn(ChannelBreadcrumbs, visible)
+}
+
+// WithVisibleInSitemap is a function that returns an Option for showing or hiding an Item in site maps.
+func WithVisibleInSitemap(visible bool) Option {
+	return WithVisibleIn(ChannelSitemap, visible)
+}
+
+// IsVisibleIn checks if the item is visible in the given channel. An empty channel shows all items.
+func (i *Item) IsVisibleIn(channel string) bool {
+	if channel == "" {
+		return true
+	}
+	if visible, ok := i.channels()[channel]; ok {
+		return visible
+	}
+	return true
+}
+
+// channels returns the channels extra, also accepting the map[string]any produced by JSON decoding.
+func (i *Item) channels() map[string]bool {
+	switch channels := ChannelsExtra.raw(i).(type) {
+	case map[string]bool:
+		return channels
+	case map[string]any:
+		m := make(map[string]bool, len(channels))
+		for name, value := range channels {
+			if visible, ok := value.(bool); ok {
+				m[name] = visible
+			}
+		}
+		return m
+	default:
+		return nil
+	}
+}
+
+// ChannelFilter returns a Filter hiding the items not visible in the given channel.
+func ChannelFilter(channel string) Filter {
+	return func(_ context.Context, item *Item) bool {
+		return item.IsVisibleIn(channel)
+	}
+}
+
+// ContextChannelFilter is a Filter hiding the items not visible in the channel carried by the context,
+// see ContextWithChannel.
+func ContextChannelFilter(ctx context.Context, item *Item) bool {
+	return item.IsVisibleIn(ChannelFromContext(ctx))
+}
@@ -0,0 +1,130 @@
+package menu
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ClassList returns the classes of a class attribute value: a string of classes separated by spaces,
+// a []string or a []any, e.g. decoded from JSON, each element possibly holding several classes.
+// The duplicates are removed, the first occurrence of a class keeping its position.
+func ClassList(value any) []string {
+	var classes []string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		classes = strings.Fields(v)
+	case []string:
+		for _, class := range v {
+			classes = append(classes, strings.Fields(class)...)
+		}
+	case []any:
+		for _, class := range v {
+			classes = append(classes, ClassList(class)...)
+		}
+	default:
+		classes = strings.Fields(fmt.Sprint(v))
+	}
+
+	unique := classes[:0]
+	for _, class := range classes {
+		if !slices.Contains(unique, class) {
+			unique = append(unique, class)
+		}
+	}
+	return unique
+}
+
+// AddClass adds the classes to the class attribute of the list element of the item, after its own classes.
+// The classes it already has are not added twice.
+func (i *Item) AddClass(classes ...string) {
+	i.Attributes = addClass(i.Attributes, classes)
+}
+
+// RemoveClass removes the classes from the class attribute of the list element of the item.
+func (i *Item) RemoveClass(classes ...string) {
+	i.Attributes = removeClass(i.Attributes, classes)
+}
+
+// HasClass checks if the class attribute of the list element of the item holds the class.
+func (i *Item) HasClass(class string) bool {
+	return slices.Contains(ClassList(i.Attributes["class"]), class)
+}
+
+// AddLinkClass adds the classes to the class attribute of the link of the item, see AddClass.
+func (i *Item) AddLinkClass(classes ...string) {
+	i.LinkAttributes = addClass(i.LinkAttributes, classes)
+}
+
+// RemoveLinkClass removes the classes from the class attribute of the link of the item.
+func (i *Item) RemoveLinkClass(classes ...string) {
+	i.LinkAttributes = removeClass(i.LinkAttributes, classes)
+}
+
+// HasLinkClass checks if the class attribute of the link of the item holds the class.
+func (i *Item) HasLinkClass(class string) bool {
+	return slices.Contains(ClassList(i.LinkAttributes["class"]), class)
+}
+
+// AddChildrenClass adds the classes to the class attribute of the list of the children of the item, see AddClass.
+func (i *Item) AddChildrenClass(classes ...string) {
+	i.ChildrenAttributes = addClass(i.ChildrenAttributes, classes)
+}
+
+// RemoveChildrenClass removes the classes from the class attribute of the list of the children of the item.
+func (i *Item) RemoveChildrenClass(classes ...string) {
+	i.ChildrenAttributes = removeClass(i.ChildrenAttributes, classes)
+}
+
+// HasChildrenClass checks if the class attribute of the list of the children of the item holds the class.
+func (i *Item) HasChildrenClass(class string) bool {
+	return slices.Contains(ClassList(i.ChildrenAttributes["class"]), class)
+}
+
+// AddLabelClass adds the classes to the class attribute of the label of the item, see AddClass.
+func (i *Item) AddLabelClass(classes ...string) {
+	i.LabelAttributes = addClass(i.LabelAttributes, classes)
+}
+
+// RemoveLabelClass removes the classes from the class attribute of the label of the item.
+func (i *Item) RemoveLabelClass(classes ...string) {
+	i.LabelAttributes = removeClass(i.LabelAttributes, classes)
+}
+
+// HasLabelClass checks if the class attribute of the label of the item holds the class.
+func (i *Item) HasLabelClass(class string) bool {
+	return slices.Contains(ClassList(i.LabelAttributes["class"]), class)
+}
+
+// addClass sets the class attribute to its classes followed by the new ones, as a string.
+func addClass(attributes map[string]any, classes []string) map[string]any {
+	merged := ClassList([]any{attributes["class"], classes})
+	if len(merged) == 0 {
+		return attributes
+	}
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	attributes["class"] = strings.Join(merged, " ")
+	return attributes
+}
+
+// removeClass sets the class attribute to its classes but the removed ones, as a string,
+// the attribute being deleted when no class remains.
+func removeClass(attributes map[string]any, classes []string) map[string]any {
+	if _, ok := attributes["class"]; !ok {
+		return attributes
+	}
+
+	removed := ClassList(classes)
+	kept := slices.DeleteFunc(ClassList(attributes["class"]), func(class string) bool {
+		return slices.Contains(removed, class)
+	})
+	if len(kept) == 0 {
+		delete(attributes, "class")
+	} else {
+		attributes["class"] = strings.Join(kept, " ")
+	}
+	return attributes
+}
@@ -0,0 +1,226 @@
+package menu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+var (
+	_ Provider      = (*ConfigProvider)(nil)
+	_ RenderBinding = (*ConfigProvider)(nil)
+)
+
+// ErrInvalidConfig is returned when a menu configuration is malformed.
+var ErrInvalidConfig = errors.New("invalid menu config")
+
+// Config describes a set of named menus: both their tree and how they should be rendered.
+//
+// Example:
+//
+//	{
+//	  "menus": {
+//	    "main": {
+//	      "render": {"renderer": "template", "preset": "bootstrap5", "options": {"depth": 2}},
+//	      "children": [
+//	        {"name": "home", "label": "Home", "uri": "/"},
+//	        {"name": "blog", "label": "Blog", "uri": "/blog", "children": [...]}
+//	      ]
+//	    }
+//	  }
+//	}
+type Config struct {
+	Menus map[string]MenuConfig `json:"menus"`
+}
+
+// MenuConfig describes a menu: its root item and its render binding.
+// The name of the root item defaults to the name of the menu.
+type MenuConfig struct {
+	ItemConfig
+	Render RenderConfig `json:"render,omitempty"`
+}
+
+// RenderConfig describes how a menu is rendered.
+type RenderConfig struct {
+	// Renderer is the type of the renderer, e.g. "list" or "template".
+	Renderer string `json:"renderer,omitempty"`
+	// Preset is the name of a registered set of render options applied before Options.
+	Preset string `json:"preset,omitempty"`
+	// Template is the template rendered by template based renderers.
+	Template string `json:"template,omitempty"`
+	// Options holds the render options, using the JSON names of the renderer options.
+	Options map[string]any `json:"options,omitempty"`
+}
+
+// ItemConfig describes an item and its children. It mirrors the fields of Item.
+type ItemConfig struct {
+	ID                 string         `json:"id,omitempty"`
+	Name               string         `json:"name,omitempty"`
+	URI                string         `json:"uri,omitempty"`
+	Label              string         `json:"label,omitempty"`
+	Position           int            `json:"position,omitempty"`
+	Display            *bool          `json:"display,omitempty"`
+	DisplayChildren    *bool          `json:"display_children,omitempty"`
+	Attributes         map[string]any `json:"attributes,omitempty"`
+	LinkAttributes     map[string]any `json:"link_attributes,omitempty"`
+	ChildrenAttributes map[string]any `json:"children_attributes,omitempty"`
+	LabelAttributes    map[string]any `json:"label_attributes,omitempty"`
+	Extras             map[string]any `json:"extras,omitempty"`
+	Children           []ItemConfig   `json:"children,omitempty"`
+}
+
+// RenderBinding is implemented by providers that know how their menus should be rendered.
+type RenderBinding interface {
+	// RenderConfig returns the render binding of the menu with the given name.
+	RenderConfig(name string) (RenderConfig, bool)
+}
+
+// ParseConfig decodes a JSON menu configuration.
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return &cfg, cfg.Validate()
+}
+
+// ReadConfig reads and decodes a JSON menu configuration.
+func ReadConfig(r io.Reader) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data)
+}
+
+// Validate checks that every menu and item has a name.
+func (c *Config) Validate() error {
+	var errs []error
+	for name, menu := range c.Menus {
+		if name == "" {
+			errs = append(errs, fmt.Errorf("%w: empty menu name", ErrInvalidConfig))
+			continue
+		}
+		errs = append(errs, menu.ItemConfig.validate(name))
+	}
+	return errors.Join(errs...)
+}
+
+func (c ItemConfig) validate(path string) error {
+	var errs []error
+	for i, child := range c.Children {
+		if child.Name == "" {
+			errs = append(errs, fmt.Errorf("%w: child %d of %q has no name", ErrInvalidConfig, i, path))
+			continue
+		}
+		errs = append(errs, child.validate(path+"/"+child.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// Options returns the options setting the fields of an item, children excluded.
+func (c ItemConfig) Options() []Option {
+	options := []Option{
+		WithURI(c.URI),
+		WithLabel(c.Label),
+		WithPosition(c.Position),
+	}
+	if c.ID != "" {
+		options = append(options, WithID(c.ID))
+	}
+	if c.Display != nil {
+		options = append(options, WithDisplay(*c.Display))
+	}
+	if c.DisplayChildren != nil {
+		options = append(options, WithDisplayChildren(*c.DisplayChildren))
+	}
+	if c.Attributes != nil {
+		options = append(options, WithAttributes(c.Attributes))
+	}
+	if c.LinkAttributes != nil {
+		options = append(options, WithLinkAttributes(c.LinkAttributes))
+	}
+	if c.ChildrenAttributes != nil {
+		options = append(options, WithChildrenAttributes(c.ChildrenAttributes))
+	}
+	if c.LabelAttributes != nil {
+		options = append(options, WithLabelAttributes(c.LabelAttributes))
+	}
+	if c.Extras != nil {
+		extras := maps.Clone(c.Extras)
+		DefaultExtras.Decode(extras)
+		options = append(options, WithExtras(extras))
+	}
+	return options
+}
+
+// Item builds the item described by the configuration and its children.
+func (c ItemConfig) Item() (*Item, error) {
+	item, err := NewItem(c.Name, c.Options()...)
+	if err != nil {
+		return nil, err
+	}
+	item.compileURIPatterns()
+
+	for _, childConfig := range c.Children {
+		child, err := childConfig.Item()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = item.AddChild(child); err != nil {
+			return nil, err
+		}
+	}
+
+	return item, nil
+}
+
+// ConfigProvider is a Provider serving the menus described by a Config.
+// Every call to Get builds a new tree, so callers are free to modify it.
+type ConfigProvider struct {
+	config *Config
+}
+
+// NewConfigProvider creates a new ConfigProvider for the given configuration.
+func NewConfigProvider(config *Config) *ConfigProvider {
+	return &ConfigProvider{config: config}
+}
+
+// Get builds the menu with the given name.
+func (p *ConfigProvider) Get(_ context.Context, name string) (*Item, error) {
+	cfg, ok := p.config.Menus[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMenuNotFound, name)
+	}
+
+	if cfg.Name == "" {
+		cfg.Name = name
+	}
+	return cfg.Item()
+}
+
+// Has checks if the configuration describes the menu with the given name.
+func (p *ConfigProvider) Has(_ context.Context, name string) bool {
+	_, ok := p.config.Menus[name]
+	return ok
+}
+
+// RenderConfig returns the render binding of the menu with the given name.
+func (p *ConfigProvider) RenderConfig(name string) (RenderConfig, bool) {
+	cfg, ok := p.config.Menus[name]
+	return cfg.Render, ok
+}
+
+// Names returns the sorted names of the configured menus.
+func (p *ConfigProvider) Names() []string {
+	names := make([]string, 0, len(p.config.Menus))
+	for name := range p.config.Menus {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,186 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// ErrItemConflict is returned by a Registry using ConflictError when contributors add items with the same name
+// under the same parent.
+var ErrItemConflict = errors.New("conflicting menu items")
+
+// ConflictPolicy defines how a Registry resolves items with the same name contributed under the same parent.
+type ConflictPolicy int
+
+const (
+	// ConflictKeep keeps all the conflicting items. Conflicts are still reported.
+	ConflictKeep ConflictPolicy = iota
+	// ConflictError fails the build of the menu with ErrItemConflict.
+	ConflictError
+	// ConflictRename keeps the first item and renames the others with a numeric suffix, e.g. "blog-2".
+	ConflictRename
+	// ConflictMerge merges the later items into the first one: their non-empty fields and attributes
+	// override the ones of the first item and their children are appended to its children.
+	ConflictMerge
+	// ConflictHighestWeight keeps the item contributed with the highest weight, the last one on a tie.
+	ConflictHighestWeight
+)
+
+// String returns the name of the policy.
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictKeep:
+		return "keep"
+	case ConflictError:
+		return "error"
+	case ConflictRename:
+		return "rename"
+	case ConflictMerge:
+		return "merge"
+	case ConflictHighestWeight:
+		return "highest-weight"
+	default:
+		return fmt.Sprintf("ConflictPolicy(%d)", int(p))
+	}
+}
+
+// Conflict describes items with the same name contributed under the same parent.
+type Conflict struct {
+	// Menu is the name of the menu.
+	Menu string
+	// Parent is the path of the parent item, see Item.PathString.
+	Parent string
+	// Name is the conflicting item name.
+	Name string
+	// Weights holds the weight of the contributor of each conflicting item, in tree order.
+	// Items added by extensions have the weight 0.
+	Weights []int
+	// Policy is the policy the conflict was resolved with.
+	Policy ConflictPolicy
+}
+
+// conflictResolver resolves the conflicts of a built menu according to a policy.
+type conflictResolver struct {
+	menu      string
+	policy    ConflictPolicy
+	weights   map[*Item]int
+	conflicts []Conflict
+}
+
+// attribute assigns the weight to the items of the tree contributed since the last call.
+func (c *conflictResolver) attribute(item *Item, weight int) {
+	if _, ok := c.weights[item]; !ok {
+		c.weights[item] = weight
+	}
+	for _, child := range item.Children {
+		c.attribute(child, weight)
+	}
+}
+
+func (c *conflictResolver) resolve(parent *Item) error {
+	groups := map[string][]*Item{}
+	var names []string
+	for _, child := range parent.Children {
+		if _, ok := groups[child.Name]; !ok {
+			names = append(names, child.Name)
+		}
+		groups[child.Name] = append(groups[child.Name], child)
+	}
+
+	for _, name := range names {
+		items := groups[name]
+		if len(items) < 2 {
+			continue
+		}
+
+		conflict := Conflict{
+			Menu:   c.menu,
+			Parent: parent.PathString("/"),
+			Name:   name,
+			Policy: c.policy,
+		}
+		for _, item := range items {
+			conflict.Weights = append(conflict.Weights, c.weights[item])
+		}
+		c.conflicts = append(c.conflicts, conflict)
+
+		switch c.policy {
+		case ConflictError:
+			return fmt.Errorf("%w: %q under %q", ErrItemConflict, name, conflict.Parent)
+		case ConflictRename:
+			for i, item := range items[1:] {
+				item.Name = uniqueName(parent, name, i+2)
+			}
+		case ConflictMerge:
+			for _, item := range items[1:] {
+				mergeItem(items[0], item)
+				parent.RemoveChild(item)
+			}
+		case ConflictHighestWeight:
+			winner := items[0]
+			for _, item := range items[1:] {
+				if c.weights[item] >= c.weights[winner] {
+					winner = item
+				}
+			}
+			for _, item := range items {
+				if item != winner {
+					parent.RemoveChild(item)
+				}
+			}
+		}
+	}
+
+	for _, child := range parent.Children {
+		if err := c.resolve(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uniqueName(parent *Item, name string, n int) string {
+	for ; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", name, n)
+		if parent.Child(candidate) == nil {
+			return candidate
+		}
+	}
+}
+
+// mergeItem merges the fields, attributes and children of src into dst.
+func mergeItem(dst, src *Item) {
+	if src.URI != "" {
+		dst.URI = src.URI
+	}
+	if src.Label != "" {
+		dst.Label = src.Label
+	}
+	if src.Position != 0 {
+		dst.Position = src.Position
+	}
+	if src.Current != nil {
+		dst.Current = src.Current
+	}
+
+	dst.Attributes = mergeMap(dst.Attributes, src.Attributes)
+	dst.LinkAttributes = mergeMap(dst.LinkAttributes, src.LinkAttributes)
+	dst.ChildrenAttributes = mergeMap(dst.ChildrenAttributes, src.ChildrenAttributes)
+	dst.LabelAttributes = mergeMap(dst.LabelAttributes, src.LabelAttributes)
+	dst.Extras = mergeMap(dst.Extras, src.Extras)
+
+	for _, child := range src.Children {
+		child.Parent = dst
+		dst.Children = append(dst.Children, child)
+	}
+	src.Children = nil
+}
+
+func mergeMap(dst, src map[string]any) map[string]any {
+	if dst == nil {
+		dst = map[string]any{}
+	}
+	maps.Copy(dst, src)
+	return dst
+}
@@ -0,0 +1,120 @@
+package menu
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// CountFunc returns the count displayed by a count label, e.g. the number of unread messages of the current user.
+type CountFunc func(ctx context.Context, item *Item) int
+
+// CountLabel describes a label built at render time from a count and the plural forms of a message, see WithCountLabel.
+type CountLabel struct {
+	// Key is the key of the message in DefaultPlurals.
+	Key string `json:"key"`
+	// Count returns the count displayed by the label.
+	Count CountFunc `json:"-"`
+}
+
+// CountLabelExtra holds the count label of an item.
+var CountLabelExtra = DefineExtra("menu", ExtraKey("menu", "count_label"), CountLabel{}, "count_label")
+
+// WithCountLabel is a function that returns an Option replacing the label of an Item at render time by the message
+// of DefaultPlurals with the given key, in the plural form matching the count, e.g. "1 message" or "5 messages".
+//
+// Example usage:
+//
+//	menu.DefaultPlurals.Set(language.English, "messages", map[plural.Form]string{
+//		plural.One:   "{count} message",
+//		plural.Other: "{count} messages",
+//	})
+//
+//	item, err := menu.NewItem("inbox", menu.WithCountLabel("messages", unreadMessages))
+func WithCountLabel(key string, count CountFunc) Option {
+	return CountLabelExtra.Option(CountLabel{Key: key, Count: count})
+}
+
+type languageKey struct{}
+
+// ContextWithLanguage returns a copy of the context carrying the language of the rendered menus.
+func ContextWithLanguage(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, languageKey{}, tag)
+}
+
+// LanguageFromContext returns the language carried by the context, or language.English.
+func LanguageFromContext(ctx context.Context) language.Tag {
+	if tag, ok := ctx.Value(languageKey{}).(language.Tag); ok {
+		return tag
+	}
+	return language.English
+}
+
+// PluralCatalog holds the plural forms of messages per language. The forms are selected with the CLDR plural rules
+// of the language, and "{count}" in a form is replaced by the count.
+type PluralCatalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[language.Tag]map[plural.Form]string
+}
+
+// DefaultPlurals is the catalog used by the count labels.
+var DefaultPlurals = NewPluralCatalog()
+
+// NewPluralCatalog creates an empty PluralCatalog.
+func NewPluralCatalog() *PluralCatalog {
+	return &PluralCatalog{messages: map[string]map[language.Tag]map[plural.Form]string{}}
+}
+
+// Set sets the plural forms of the message with the given key in the language.
+func (c *PluralCatalog) Set(tag language.Tag, key string, forms map[plural.Form]string) *PluralCatalog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages[key] == nil {
+		c.messages[key] = map[language.Tag]map[plural.Form]string{}
+	}
+	c.messages[key][tag] = forms
+	return c
+}
+
+// Format returns the message with the given key in the plural form matching the count in the language.
+// The message falls back to the parent languages, e.g. "en" for "en-GB", and the form falls back to plural.Other.
+// Unknown messages are formatted as the count followed by the key.
+func (c *PluralCatalog) Format(tag language.Tag, key string, count int) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	n := count
+	if n < 0 {
+		n = -n
+	}
+
+	for t := tag; ; t = t.Parent() {
+		if forms, ok := c.messages[key][t]; ok {
+			form := plural.Cardinal.MatchPlural(t, n, 0, 0, 0, 0)
+			message, ok := forms[form]
+			if !ok {
+				message = forms[plural.Other]
+			}
+			return strings.ReplaceAll(message, "{count}", strconv.Itoa(count))
+		}
+		if t == language.Und {
+			break
+		}
+	}
+
+	return strconv.Itoa(count) + " " + key
+}
+
+// ResolveLabel returns the label displayed for the item: the count label in the language of the context when
+// the item has one, see WithCountLabel, otherwise the label with its variables resolved, see InterpolateLabel.
+func (i *Item) ResolveLabel(ctx context.Context, escape func(string) string) string {
+	if label, ok := CountLabelExtra.Lookup(i); ok && label.Count != nil {
+		return DefaultPlurals.Format(LanguageFromContext(ctx), label.Key, label.Count(ctx, i))
+	}
+	return i.InterpolateLabel(ctx, escape)
+}
@@ -0,0 +1,112 @@
+package menu
+
+import (
+	"reflect"
+	"slices"
+)
+
+// EqualOption customizes the comparison made by Item.Equal.
+type EqualOption func(*equalOptions)
+
+type equalOptions struct {
+	ignoreExtras   bool
+	ignoreParents  bool
+	ignoreChildren bool
+	renderedOnly   bool
+}
+
+// IgnoreExtras is an EqualOption skipping the comparison of the extras.
+func IgnoreExtras() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreExtras = true
+	}
+}
+
+// IgnoreParents is an EqualOption skipping the comparison of the paths of the compared items,
+// so that equal subtrees found at different places of a menu, or in different menus, are equal.
+func IgnoreParents() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreParents = true
+	}
+}
+
+// IgnoreChildren is an EqualOption comparing the items only, not their descendants.
+func IgnoreChildren() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreChildren = true
+	}
+}
+
+// RenderedFields is an EqualOption comparing only the fields that change the output of the renderers:
+// the ID, Name and Position fields are ignored, the order of the children being compared instead.
+func RenderedFields() EqualOption {
+	return func(o *equalOptions) {
+		o.renderedOnly = true
+	}
+}
+
+// Equal reports whether the item and its descendants are equal to the other ones.
+//
+// By default, all the exported fields are compared, the Current field by value, and the parents by path:
+// the compared items must have the same path from their roots. Attribute and extras maps are compared
+// with reflect.DeepEqual, a nil map being equal to an empty one.
+//
+// Example usage:
+//
+//	if !cached.Equal(fresh, menu.IgnoreParents(), menu.RenderedFields()) {
+//	    cache.Invalidate(name)
+//	}
+func (i *Item) Equal(other *Item, options ...EqualOption) bool {
+	var o equalOptions
+	for _, option := range options {
+		option(&o)
+	}
+
+	if i == nil || other == nil {
+		return i == other
+	}
+	if !o.ignoreParents && !slices.Equal(i.Path(), other.Path()) {
+		return false
+	}
+	return i.equal(other, &o)
+}
+
+func (i *Item) equal(other *Item, o *equalOptions) bool {
+	if !o.renderedOnly && (i.ID != other.ID || i.Name != other.Name || i.Position != other.Position) {
+		return false
+	}
+
+	if i.URI != other.URI ||
+		i.Label != other.Label ||
+		i.Display != other.Display ||
+		i.DisplayChildren != other.DisplayChildren ||
+		i.IsCurrent() != other.IsCurrent() || (i.Current == nil) != (other.Current == nil) {
+		return false
+	}
+
+	if !equalMaps(i.Attributes, other.Attributes) ||
+		!equalMaps(i.LinkAttributes, other.LinkAttributes) ||
+		!equalMaps(i.ChildrenAttributes, other.ChildrenAttributes) ||
+		!equalMaps(i.LabelAttributes, other.LabelAttributes) {
+		return false
+	}
+
+	if !o.ignoreExtras && !equalMaps(i.Extras, other.Extras) {
+		return false
+	}
+
+	if o.ignoreChildren {
+		return true
+	}
+
+	return slices.EqualFunc(i.Children, other.Children, func(a, b *Item) bool {
+		return a.equal(b, o)
+	})
+}
+
+func equalMaps(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -3,21 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
-	"html/template"
-	"io/fs"
 	"net/url"
-	"strings"
-
-	sprig "github.com/go-task/slim-sprig"
 
 	"github.com/gowool/menu"
 	"github.com/gowool/menu/renderer"
-	"github.com/gowool/menu/views"
 )
 
 func main() {
 	u, _ := url.Parse("http://localhost/blog/article-test-1")
-	ctx := context.WithValue(context.Background(), "url", u)
+	ctx := menu.WithRequestURL(context.Background(), u)
 
 	item := menu.Must(menu.NewItem("root",
 		menu.WithChild(menu.Must(menu.NewItem("home",
@@ -44,62 +38,19 @@ func main() {
 
 	matcher := menu.NewCoreMatcher(menu.URLVoter{})
 
-	printMenu(ctx, renderer.NewTemplateRenderer(newTheme(), matcher), item)
-	printMenu(ctx, renderer.NewListRenderer(matcher), item)
-}
-
-func printMenu(ctx context.Context, render renderer.Renderer, item *menu.Item) {
-	str, err := render.Render(ctx, item)
+	theme, err := renderer.NewHTMLTheme(nil)
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(str)
-}
-
-type Theme struct {
-	t *template.Template
-}
-
-func newTheme() Theme {
-	return Theme{t: buildTemplate()}
-}
-
-func (t Theme) HTML(_ context.Context, name string, data any) (string, error) {
-	var b strings.Builder
-	err := t.t.ExecuteTemplate(&b, name, data)
-	return b.String(), err
-}
 
-func buildTemplate() *template.Template {
-	funcMap := sprig.FuncMap()
-	funcMap["raw"] = func(s string) template.HTML {
-		return template.HTML(s)
-	}
-
-	files, err := fs.Glob(views.FS, "menu/*.html")
-	if err != nil {
-		panic(err)
-	}
-
-	t := template.Must(template.
-		New(renderer.MenuTemplate).
-		Funcs(funcMap).
-		Parse(readFile(renderer.MenuTemplate[1:])))
-
-	for _, f := range files {
-		if f == renderer.MenuTemplate[1:] {
-			continue
-		}
-		_ = template.Must(t.New("@" + f).Parse(readFile(f)))
-	}
-
-	return t
+	printMenu(ctx, renderer.NewTemplateRenderer(theme, matcher), item)
+	printMenu(ctx, renderer.NewListRenderer(matcher), item)
 }
 
-func readFile(path string) string {
-	data, err := fs.ReadFile(views.FS, path)
+func printMenu(ctx context.Context, render renderer.Renderer, item *menu.Item) {
+	str, err := render.Render(ctx, item)
 	if err != nil {
 		panic(err)
 	}
-	return string(data)
+	fmt.Println(str)
 }
@@ -0,0 +1,291 @@
+package menu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrExtraConflict is returned when an extra key is declared twice with different owners or types.
+var ErrExtraConflict = errors.New("menu extra already declared")
+
+// ErrExtraType is returned when the value of a declared extra does not have the declared type.
+var ErrExtraType = errors.New("menu extra has invalid type")
+
+// ExtraSeparator separates the namespace from the name in namespaced extra keys, e.g. "seo.priority".
+const ExtraSeparator = "."
+
+// ExtraKey returns the namespaced extra key for the given namespace and name, e.g. ExtraKey("seo", "priority") is "seo.priority".
+func ExtraKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + ExtraSeparator + name
+}
+
+// SplitExtraKey splits a namespaced extra key into its namespace and name.
+// Keys without a namespace, such as the former bare keys of the built-in extras, e.g. "safe_label",
+// return an empty namespace.
+func SplitExtraKey(key string) (namespace, name string) {
+	if i := strings.Index(key, ExtraSeparator); i >= 0 {
+		return key[:i], key[i+len(ExtraSeparator):]
+	}
+	return "", key
+}
+
+// ExtraInfo describes an extra declared in an ExtrasRegistry.
+// Aliases are other keys the extra is read from, e.g. the keys it had before being namespaced.
+type ExtraInfo struct {
+	Key         string
+	Aliases     []string
+	Owner       string
+	Type        reflect.Type
+	Default     any
+	Description string
+}
+
+// ExtrasRegistry holds the extras declared by the subsystems using them, so that two plugins using the same key
+// for different purposes are detected at declaration time instead of silently overwriting each other's data.
+type ExtrasRegistry struct {
+	mu      sync.RWMutex
+	defs    map[string]ExtraInfo
+	aliases map[string]string
+}
+
+// NewExtrasRegistry creates an empty ExtrasRegistry.
+func NewExtrasRegistry() *ExtrasRegistry {
+	return &ExtrasRegistry{
+		defs:    map[string]ExtraInfo{},
+		aliases: map[string]string{},
+	}
+}
+
+// DefaultExtras is the registry the built-in extras and the ones created by DefineExtra are declared in.
+var DefaultExtras = NewExtrasRegistry()
+
+// Built-in item extras. Prefer these typed accessors to reading Item.Extras with string keys,
+// so typos are caught by the compiler instead of silently falling back to defaults.
+// The built-in extras are namespaced, e.g. "menu.safe_label", and still read from their former bare keys,
+// e.g. "safe_label", which ExtrasRegistry.Decode renames in decoded documents.
+var (
+	// SafeLabelExtra marks the label of an item as safe HTML, rendered without escaping
+	// when the renderer allows safe labels.
+	SafeLabelExtra = DefineExtra("menu", ExtraKey("menu", "safe_label"), false, "safe_label")
+
+	// RoutesExtra holds the names of the routes for which an item is current.
+	RoutesExtra = DefineExtra("menu", ExtraKey("menu", "routes"), []string(nil), "routes")
+
+	// TrustedURIExtra marks the URI of an item as trusted, rendered by html/template themes
+	// without filtering its scheme, e.g. for tel: or custom application schemes.
+	TrustedURIExtra = DefineExtra("menu", ExtraKey("menu", "trusted_uri"), false, "trusted_uri")
+)
+
+// Register declares an extra. Declaring the same key again with the same owner and type is a no-op,
+// any other redeclaration returns ErrExtraConflict, as does a key or an alias already used by another extra.
+func (r *ExtrasRegistry) Register(info ExtraInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if def, ok := r.defs[info.Key]; ok {
+		if def.Owner == info.Owner && def.Type == info.Type {
+			return nil
+		}
+		return fmt.Errorf("%w: %q is declared by %q as %v", ErrExtraConflict, info.Key, def.Owner, def.Type)
+	}
+
+	for _, key := range append([]string{info.Key}, info.Aliases...) {
+		if target, ok := r.aliases[key]; ok {
+			return fmt.Errorf("%w: %q is an alias of %q", ErrExtraConflict, key, target)
+		}
+	}
+	for _, alias := range info.Aliases {
+		if def, ok := r.defs[alias]; ok {
+			return fmt.Errorf("%w: %q is declared by %q as %v", ErrExtraConflict, alias, def.Owner, def.Type)
+		}
+	}
+
+	r.defs[info.Key] = info
+	for _, alias := range info.Aliases {
+		r.aliases[alias] = info.Key
+	}
+	return nil
+}
+
+// Lookup returns the declaration of an extra key or of one of its aliases.
+func (r *ExtrasRegistry) Lookup(key string) (ExtraInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if target, ok := r.aliases[key]; ok {
+		key = target
+	}
+	info, ok := r.defs[key]
+	return info, ok
+}
+
+// Declared returns all declared extras sorted by key.
+func (r *ExtrasRegistry) Declared() []ExtraInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]ExtraInfo, 0, len(r.defs))
+	for _, info := range r.defs {
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b ExtraInfo) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return infos
+}
+
+// Validate checks the extras of the item and its descendants against their declarations.
+// It returns an ErrExtraType error for every declared extra holding a value of another type.
+// Undeclared extras are ignored.
+func (r *ExtrasRegistry) Validate(item *Item) error {
+	var errs []error
+	for name, value := range item.Extras {
+		info, ok := r.Lookup(name)
+		if !ok || info.Type == nil || value == nil || reflect.TypeOf(value).AssignableTo(info.Type) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%w: %s of item %q is %T, expected %v", ErrExtraType, name, item.PathString("/"), value, info.Type))
+	}
+	for _, child := range item.Children {
+		if err := r.Validate(child); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Decode converts the declared extras of a decoded document to their declared types in place,
+// e.g. the []any produced by JSON or YAML decoding to the []string of RoutesExtra.
+// The extras stored under an alias are moved to their key, unless the key is set too.
+// Values that cannot be converted and undeclared extras are left untouched, Validate reports the former.
+func (r *ExtrasRegistry) Decode(extras map[string]any) {
+	for name, value := range extras {
+		info, ok := r.Lookup(name)
+		if !ok {
+			continue
+		}
+		if name != info.Key {
+			delete(extras, name)
+			if _, ok := extras[info.Key]; ok {
+				continue
+			}
+			name = info.Key
+			extras[name] = value
+		}
+		if info.Type == nil || value == nil || reflect.TypeOf(value).AssignableTo(info.Type) {
+			continue
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		typed := reflect.New(info.Type)
+		if err = json.Unmarshal(raw, typed.Interface()); err != nil {
+			continue
+		}
+		extras[name] = typed.Elem().Interface()
+	}
+}
+
+// Extra is a typed accessor for an item extra declared with DefineExtra.
+type Extra[T any] struct {
+	key     string
+	aliases []string
+	def     T
+}
+
+// DefineExtra declares an extra of type T in DefaultExtras and returns its typed accessor.
+// The extra is also read from the aliases, e.g. the key it had before being renamed, see ExtraInfo.
+// It is meant to be called from package-level variable declarations and panics on conflicting declarations.
+//
+// Example usage:
+//
+//	var Priority = menu.DefineExtra("seo", menu.ExtraKey("seo", "priority"), 0.5, "priority")
+//
+//	priority := Priority.Get(item)
+func DefineExtra[T any](owner, key string, def T, aliases ...string) Extra[T] {
+	if err := DefaultExtras.Register(ExtraInfo{
+		Key:     key,
+		Aliases: aliases,
+		Owner:   owner,
+		Type:    reflect.TypeFor[T](),
+		Default: def,
+	}); err != nil {
+		panic(err)
+	}
+	return Extra[T]{key: key, aliases: aliases, def: def}
+}
+
+// Key returns the key of the extra.
+func (e Extra[T]) Key() string {
+	return e.key
+}
+
+// Default returns the value used when an item does not hold the extra.
+func (e Extra[T]) Default() T {
+	return e.def
+}
+
+// Get returns the value of the extra for the item, or the default value if the item
+// does not hold the extra or holds a value of another type.
+func (e Extra[T]) Get(item *Item) T {
+	if value, ok := e.raw(item).(T); ok {
+		return value
+	}
+	return e.def
+}
+
+// Lookup returns the value of the extra for the item and whether the item holds a value of type T.
+func (e Extra[T]) Lookup(item *Item) (T, bool) {
+	value, ok := e.raw(item).(T)
+	return value, ok
+}
+
+// raw returns the value stored under the key of the extra, or else under the first of its aliases the item holds.
+func (e Extra[T]) raw(item *Item) any {
+	if value, ok := item.Extras[e.key]; ok {
+		return value
+	}
+	for _, alias := range e.aliases {
+		if value, ok := item.Extras[alias]; ok {
+			return value
+		}
+	}
+	return nil
+}
+
+// Set stores the value of the extra on the item, under its key, removing the values stored under its aliases.
+func (e Extra[T]) Set(item *Item, value T) {
+	if item.Extras == nil {
+		item.Extras = map[string]any{}
+	}
+	for _, alias := range e.aliases {
+		delete(item.Extras, alias)
+	}
+	item.Extras[e.key] = value
+}
+
+// Delete removes the extra from the item, under its key and its aliases.
+func (e Extra[T]) Delete(item *Item) {
+	delete(item.Extras, e.key)
+	for _, alias := range e.aliases {
+		delete(item.Extras, alias)
+	}
+}
+
+// Option returns an Option storing the value of the extra on an Item.
+func (e Extra[T]) Option(value T) Option {
+	return func(item *Item) error {
+		e.Set(item, value)
+		return nil
+	}
+}
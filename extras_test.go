@@ -0,0 +1,37 @@
+package menu
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtraAliases(t *testing.T) {
+	item, err := NewItem("item", WithExtra("roles", []string{"admin"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roles := RolesExtra.Get(item); !slices.Equal(roles, []string{"admin"}) {
+		t.Errorf("RolesExtra.Get() = %v, want the value of the former key", roles)
+	}
+
+	RolesExtra.Set(item, []string{"editor"})
+	if _, ok := item.Extras["roles"]; ok {
+		t.Errorf("RolesExtra.Set() kept the value of the former key")
+	}
+	if roles, ok := item.Extra("roles").([]string); !ok || !slices.Equal(roles, []string{"editor"}) {
+		t.Errorf("Item.Extra(%q) = %v, want the value of %q", "roles", item.Extra("roles"), RolesExtra.Key())
+	}
+
+	extras := map[string]any{"uri_pattern": []any{"/blog/*"}, "menu.badge": "new", "badge": "old"}
+	DefaultExtras.Decode(extras)
+	want := map[string]any{"menu.uri_pattern": []string{"/blog/*"}, "menu.badge": "new"}
+	if len(extras) != len(want) {
+		t.Fatalf("Decode() = %v, want %v", extras, want)
+	}
+	if patterns, _ := extras["menu.uri_pattern"].([]string); !slices.Equal(patterns, []string{"/blog/*"}) {
+		t.Errorf("Decode() = %v, want %v", extras, want)
+	}
+	if extras["menu.badge"] != "new" {
+		t.Errorf("Decode() = %v, want the key to take precedence over the alias", extras)
+	}
+}
@@ -0,0 +1,231 @@
+// Package feed exports menu trees as RSS 2.0 and Atom feeds of links, e.g. for "latest sections" widgets
+// or link blogs reusing the menu model.
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"time"
+
+	"github.com/gowool/menu"
+)
+
+// DescriptionExtra holds the description of an item, exported as the description of its feed entry.
+var DescriptionExtra = menu.DefineExtra("feed", menu.ExtraKey("feed", "description"), "", "description")
+
+// Options represents the metadata of a feed.
+type Options struct {
+	Title       string
+	Description string
+	Author      string
+	// BaseURL resolves the relative URIs of the items into the absolute links required by feeds.
+	BaseURL *url.URL
+	// Updated is the update time of the feed and its entries, the time of the export by default.
+	Updated time.Time
+	// Filters hides the items they reject from the feed, see menu.Filter.
+	Filters []menu.Filter
+}
+
+// Option represents a function that modifies an *Options object.
+type Option func(*Options)
+
+// WithTitle returns an Option setting the title of the feed. The label of the root item is used by default.
+func WithTitle(title string) Option {
+	return func(options *Options) {
+		options.Title = title
+	}
+}
+
+// WithDescription returns an Option setting the description of the feed.
+func WithDescription(description string) Option {
+	return func(options *Options) {
+		options.Description = description
+	}
+}
+
+// WithAuthor returns an Option setting the author of the feed, required by Atom.
+func WithAuthor(author string) Option {
+	return func(options *Options) {
+		options.Author = author
+	}
+}
+
+// WithBaseURL returns an Option setting the URL the relative URIs of the items are resolved against.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(options *Options) {
+		options.BaseURL = baseURL
+	}
+}
+
+// WithUpdated returns an Option setting the update time of the feed.
+func WithUpdated(updated time.Time) Option {
+	return func(options *Options) {
+		options.Updated = updated
+	}
+}
+
+// WithFilters returns an Option adding filters hiding items from the feed.
+func WithFilters(filters ...menu.Filter) Option {
+	return func(options *Options) {
+		options.Filters = append(options.Filters, filters...)
+	}
+}
+
+func newOptions(root *menu.Item, options []Option) *Options {
+	o := &Options{
+		Title:   root.Label,
+		Updated: time.Now(),
+	}
+	if o.Title == "" {
+		o.Title = root.String()
+	}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
+// entries returns the visible descendants of the root having a URI, in depth-first order.
+func entries(ctx context.Context, root *menu.Item, options *Options) []*menu.Item {
+	var items []*menu.Item
+	var walk func(item *menu.Item)
+	walk = func(item *menu.Item) {
+		for _, child := range item.VisibleChildren(ctx, options.Filters...) {
+			if child.URI != "" {
+				items = append(items, child)
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+	return items
+}
+
+func (o *Options) link(uri string) string {
+	if o.BaseURL == nil {
+		return uri
+	}
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	return o.BaseURL.ResolveReference(ref).String()
+}
+
+func title(item *menu.Item) string {
+	if item.Label != "" {
+		return item.Label
+	}
+	return item.String()
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description,omitempty"`
+	GUID        string `xml:"guid"`
+}
+
+// RSS exports the visible descendants of the root having a URI as an RSS 2.0 feed.
+func RSS(ctx context.Context, root *menu.Item, options ...Option) ([]byte, error) {
+	o := newOptions(root, options)
+
+	feed := rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         o.Title,
+			Link:          o.link(root.URI),
+			Description:   o.Description,
+			LastBuildDate: o.Updated.Format(time.RFC1123Z),
+		},
+	}
+	for _, item := range entries(ctx, root, o) {
+		link := o.link(item.URI)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       title(item),
+			Link:        link,
+			Description: DescriptionExtra.Get(item),
+			GUID:        link,
+		})
+	}
+
+	return marshal(feed)
+}
+
+type atom struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary,omitempty"`
+}
+
+// Atom exports the visible descendants of the root having a URI as an Atom feed.
+func Atom(ctx context.Context, root *menu.Item, options ...Option) ([]byte, error) {
+	o := newOptions(root, options)
+	updated := o.Updated.UTC().Format(time.RFC3339)
+	link := o.link(root.URI)
+
+	feed := atom{
+		ID:      link,
+		Title:   o.Title,
+		Updated: updated,
+		Link:    atomLink{Href: link},
+	}
+	if o.Author != "" {
+		feed.Author = &atomAuthor{Name: o.Author}
+	}
+	for _, item := range entries(ctx, root, o) {
+		link := o.link(item.URI)
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      link,
+			Title:   title(item),
+			Updated: updated,
+			Link:    atomLink{Href: link},
+			Summary: DescriptionExtra.Get(item),
+		})
+	}
+
+	return marshal(feed)
+}
+
+func marshal(v any) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,89 @@
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gowool/menu"
+)
+
+func feedTree(t *testing.T) *menu.Item {
+	t.Helper()
+
+	root, err := menu.NewItem("root", menu.WithLabel("Latest"), menu.WithURI("/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blog, _ := root.AddChild("blog", menu.WithLabel("Blog"), menu.WithURI("/blog"), DescriptionExtra.Option("All the posts"))
+	_, _ = blog.AddChild("post", menu.WithURI("https://example.org/post"))
+	_, _ = root.AddChild("group")
+	_, _ = root.AddChild("draft", menu.WithURI("/draft"), menu.WithDisplay(false))
+	_, _ = root.AddChild("admin", menu.WithURI("/admin"))
+	return root
+}
+
+var (
+	baseURL, _ = url.Parse("https://example.com/")
+	updated    = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	noAdmin    = func(_ context.Context, item *menu.Item) bool { return item.Name != "admin" }
+)
+
+func TestRSS(t *testing.T) {
+	data, err := RSS(context.Background(), feedTree(t), WithBaseURL(baseURL), WithUpdated(updated), WithFilters(noAdmin))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var feed rss
+	if err = xml.Unmarshal(data, &feed); err != nil {
+		t.Fatal(err)
+	}
+	if feed.Version != "2.0" || feed.Channel.Title != "Latest" || feed.Channel.Link != "https://example.com/" ||
+		feed.Channel.LastBuildDate != updated.Format(time.RFC1123Z) {
+		t.Errorf("channel = %+v", feed.Channel)
+	}
+
+	want := []rssItem{
+		{Title: "Blog", Link: "https://example.com/blog", Description: "All the posts", GUID: "https://example.com/blog"},
+		{Title: "post", Link: "https://example.org/post", GUID: "https://example.org/post"},
+	}
+	if len(feed.Channel.Items) != len(want) {
+		t.Fatalf("RSS() has %d items, want %d: %+v", len(feed.Channel.Items), len(want), feed.Channel.Items)
+	}
+	for i, item := range feed.Channel.Items {
+		if item != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, item, want[i])
+		}
+	}
+}
+
+func TestAtom(t *testing.T) {
+	data, err := Atom(context.Background(), feedTree(t), WithTitle("News"), WithAuthor("Acme"), WithBaseURL(baseURL), WithUpdated(updated))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var feed atom
+	if err = xml.Unmarshal(data, &feed); err != nil {
+		t.Fatal(err)
+	}
+	if feed.Title != "News" || feed.Author == nil || feed.Author.Name != "Acme" || feed.Updated != "2024-05-01T12:00:00Z" {
+		t.Errorf("feed = %+v", feed)
+	}
+
+	var links []string
+	for _, entry := range feed.Entries {
+		links = append(links, entry.Link.Href)
+	}
+	want := []string{"https://example.com/blog", "https://example.org/post", "https://example.com/admin"}
+	if !slices.Equal(links, want) {
+		t.Fatalf("Atom() links = %v, want %v", links, want)
+	}
+	if feed.Entries[0].Summary != "All the posts" {
+		t.Errorf("summary = %q, want the description extra", feed.Entries[0].Summary)
+	}
+}
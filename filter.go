@@ -0,0 +1,42 @@
+package menu
+
+import "context"
+
+// Filter reports whether an item is visible in the given context, e.g. depending on the permissions
+// of the current user. Filters are applied at render time, so a shared tree does not need to be pruned per request.
+type Filter func(ctx context.Context, item *Item) bool
+
+// IsVisible checks if the item is displayed and accepted by all the filters.
+func (i *Item) IsVisible(ctx context.Context, filters ...Filter) bool {
+	if !i.Display {
+		return false
+	}
+	for _, filter := range filters {
+		if !filter(ctx, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// VisibleChildren returns the children of the item that are visible according to IsVisible.
+func (i *Item) VisibleChildren(ctx context.Context, filters ...Filter) []*Item {
+	children := make([]*Item, 0, len(i.Children))
+	for _, child := range i.Children {
+		if child.IsVisible(ctx, filters...) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// HasVisibleChildren checks if at least one child of the item is visible according to IsVisible.
+// Without filters, it is equivalent to HasChildren.
+func (i *Item) HasVisibleChildren(ctx context.Context, filters ...Filter) bool {
+	for _, child := range i.Children {
+		if child.IsVisible(ctx, filters...) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package menu
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// Hash returns a stable content hash of the item and its descendants, the hex encoded SHA-256 of their fields:
+// identifiers, names, URIs, labels, positions, display flags, current states, attributes, extras and the order
+// of the children. The parents are not part of the hash, so equal subtrees have the same hash wherever they are.
+//
+// The hash tells whether a tree changed without keeping a copy of it for a deep comparison, e.g. to key
+// render caches or to compute ETags. It is stable across processes as long as the attribute and extras values
+// encode to the same JSON, values that cannot be encoded are hashed with their Go syntax representation.
+//
+// Example usage:
+//
+//	w.Header().Set("ETag", `"`+root.Hash()+`"`)
+func (i *Item) Hash() string {
+	h := sha256.New()
+	i.hash(h)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (i *Item) hash(h hash.Hash) {
+	writeHash(h, i.ID)
+	writeHash(h, i.Name)
+	writeHash(h, i.URI)
+	writeHash(h, i.Label)
+	writeHash(h, strconv.Itoa(i.Position))
+	writeHash(h, strconv.FormatBool(i.Display))
+	writeHash(h, strconv.FormatBool(i.DisplayChildren))
+	if i.Current == nil {
+		writeHash(h, "")
+	} else {
+		writeHash(h, strconv.FormatBool(*i.Current))
+	}
+
+	for _, m := range []map[string]any{i.Attributes, i.LinkAttributes, i.ChildrenAttributes, i.LabelAttributes, i.Extras} {
+		hashMap(h, m)
+	}
+
+	writeHash(h, strconv.Itoa(len(i.Children)))
+	for _, child := range i.Children {
+		child.hash(h)
+	}
+}
+
+// hashMap writes the entries of the map sorted by key, a nil map being hashed as an empty one.
+func hashMap(h hash.Hash, m map[string]any) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	writeHash(h, strconv.Itoa(len(keys)))
+	for _, key := range keys {
+		writeHash(h, key)
+		if data, err := json.Marshal(m[key]); err == nil {
+			writeHash(h, string(data))
+		} else {
+			writeHash(h, fmt.Sprintf("%#v", m[key]))
+		}
+	}
+}
+
+// writeHash writes the length-prefixed value, so the boundaries of the values are part of the hash.
+func writeHash(h hash.Hash, value string) {
+	_, _ = io.WriteString(h, strconv.Itoa(len(value)))
+	_, _ = io.WriteString(h, ":")
+	_, _ = io.WriteString(h, value)
+}
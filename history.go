@@ -0,0 +1,210 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	_ Provider     = (*HistoryProvider)(nil)
+	_ HistoryStore = (*MemoryHistory)(nil)
+)
+
+// Visit is a visit of the current item of a menu, recorded by HistoryProvider.
+type Visit struct {
+	Key   string    `json:"key"`
+	Label string    `json:"label"`
+	URI   string    `json:"uri"`
+	At    time.Time `json:"at"`
+}
+
+// HistoryStore stores the visits of the users, e.g. in their session.
+type HistoryStore interface {
+	// Record records a visit of an item of the menu with the given name.
+	Record(ctx context.Context, menu string, visit Visit) error
+
+	// Recent returns at most limit visits of distinct items of the menu, the most recent first.
+	Recent(ctx context.Context, menu string, limit int) ([]Visit, error)
+}
+
+type sessionKey struct{}
+
+// WithSessionID returns a copy of the context carrying the session of the user, used by MemoryHistory.
+func WithSessionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, id)
+}
+
+// SessionIDFromContext returns the session of the user carried by the context, or an empty string.
+func SessionIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(sessionKey{}).(string)
+	return id
+}
+
+// MemoryHistory is an in-memory HistoryStore keeping the last visits of every session, see WithSessionID.
+// Visits without a session are not recorded.
+type MemoryHistory struct {
+	mu     sync.Mutex
+	size   int
+	visits map[string][]Visit
+}
+
+// NewMemoryHistory creates a new MemoryHistory keeping at most size visits per session and menu.
+func NewMemoryHistory(size int) *MemoryHistory {
+	return &MemoryHistory{
+		size:   size,
+		visits: map[string][]Visit{},
+	}
+}
+
+// Record records the visit, replacing the previous visit of the same item.
+func (h *MemoryHistory) Record(ctx context.Context, menu string, visit Visit) error {
+	session := SessionIDFromContext(ctx)
+	if session == "" {
+		return nil
+	}
+	key := session + "\x00" + menu
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	visits := slices.DeleteFunc(h.visits[key], func(v Visit) bool { return v.Key == visit.Key })
+	visits = append([]Visit{visit}, visits...)
+	if len(visits) > h.size {
+		visits = visits[:h.size]
+	}
+	h.visits[key] = visits
+	return nil
+}
+
+// Recent returns the last visits of the session.
+func (h *MemoryHistory) Recent(ctx context.Context, menu string, limit int) ([]Visit, error) {
+	session := SessionIDFromContext(ctx)
+	if session == "" {
+		return nil, nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	visits := h.visits[session+"\x00"+menu]
+	return slices.Clone(visits[:min(limit, len(visits))]), nil
+}
+
+// RecentConfig configures the "Recent" submenu synthesized by HistoryProvider.
+type RecentConfig struct {
+	// Name is the name of the submenu, "recent" when empty.
+	Name string
+	// Label is the label of the submenu, "Recent" when empty.
+	Label string
+	// Limit is the maximum number of items of the submenu, 5 when zero.
+	Limit int
+	// Position is the position of the submenu among the children of the root.
+	Position int
+	// IncludeCurrent keeps the current item in the submenu, it is skipped by default.
+	IncludeCurrent bool
+}
+
+func (c RecentConfig) withDefaults() RecentConfig {
+	if c.Name == "" {
+		c.Name = "recent"
+	}
+	if c.Label == "" {
+		c.Label = "Recent"
+	}
+	if c.Limit <= 0 {
+		c.Limit = 5
+	}
+	return c
+}
+
+// HistoryProvider is a Provider recording the visits of the current items of its menus to a HistoryStore,
+// and exposing them as a synthesized "Recent" submenu appended to the menus it is enabled for, see Enable.
+//
+// The menus the feature is enabled for are copied on every call to Get, since the submenu depends on the user.
+type HistoryProvider struct {
+	provider Provider
+	store    HistoryStore
+	voters   []Voter
+	mu       sync.RWMutex
+	menus    map[string]RecentConfig
+}
+
+// NewHistoryProvider creates a new HistoryProvider. The voters detect the current item of a menu.
+func NewHistoryProvider(provider Provider, store HistoryStore, voters ...Voter) *HistoryProvider {
+	return &HistoryProvider{
+		provider: provider,
+		store:    store,
+		voters:   voters,
+		menus:    map[string]RecentConfig{},
+	}
+}
+
+// Enable enables the recording of the visits and the "Recent" submenu for the menu with the given name.
+func (p *HistoryProvider) Enable(name string, config RecentConfig) *HistoryProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.menus[name] = config.withDefaults()
+	return p
+}
+
+// Get returns the menu of the underlying provider. For the enabled menus, it returns a copy completed with
+// the "Recent" submenu, built from the visits of the store, after recording the visit of its current item.
+func (p *HistoryProvider) Get(ctx context.Context, name string) (*Item, error) {
+	root, err := p.provider.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	config, ok := p.menus[name]
+	p.mu.RUnlock()
+	if !ok {
+		return root, nil
+	}
+
+	if root, err = root.Copy(); err != nil {
+		return nil, err
+	}
+
+	var currentKey string
+	if current := findCurrent(ctx, NewCoreMatcher(p.voters...), root); current != nil {
+		currentKey = current.Key()
+		if err = p.store.Record(ctx, name, Visit{Key: currentKey, Label: current.Label, URI: current.URI, At: time.Now()}); err != nil {
+			return nil, fmt.Errorf("record visit of %s: %w", currentKey, err)
+		}
+	}
+
+	visits, err := p.store.Recent(ctx, name, config.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := NewItem(config.Name, WithLabel(config.Label), WithPosition(config.Position))
+	if err != nil {
+		return nil, err
+	}
+	for i, visit := range visits {
+		if visit.Key == currentKey && !config.IncludeCurrent || len(recent.Children) == config.Limit {
+			continue
+		}
+		if _, err = recent.AddChild(fmt.Sprintf("%s-%d", config.Name, i), WithLabel(visit.Label), WithURI(visit.URI)); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(recent.Children) > 0 {
+		if _, err = root.AddChild(recent); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// Has checks if the underlying provider knows the menu with the given name.
+func (p *HistoryProvider) Has(ctx context.Context, name string) bool {
+	return p.provider.Has(ctx, name)
+}
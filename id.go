@@ -0,0 +1,187 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrDuplicateItemID is returned when an item is added to a menu that already contains an item with the same ID.
+var ErrDuplicateItemID = errors.New("menu item id already exists in the menu")
+
+// idIndex is the root-level index of the items of a menu by ID.
+type idIndex struct {
+	mu  sync.RWMutex
+	ids map[string]*Item
+}
+
+func newIDIndex() *idIndex {
+	return &idIndex{ids: map[string]*Item{}}
+}
+
+// NewID returns a new random (version 4) UUID suitable as an item ID.
+func NewID() string {
+	return uuid.NewString()
+}
+
+// WithID is a function that returns an Option for setting the stable ID of an Item.
+// IDs must be unique across a menu tree, see Item.ByID.
+func WithID(id string) Option {
+	return func(item *Item) error {
+		item.ID = id
+		return nil
+	}
+}
+
+// WithAutoID is a function that returns an Option assigning a new UUID to an Item that has no ID yet.
+func WithAutoID() Option {
+	return func(item *Item) error {
+		if item.ID == "" {
+			item.ID = NewID()
+		}
+		return nil
+	}
+}
+
+// ByID returns the item with the given ID from the menu tree the item belongs to, or nil if there is none.
+// The lookup is served by an index maintained on the root item by AddChild, RemoveChild and SetID.
+// An ID assigned to the ID field of an item already added to a menu is not indexed until Reindex is called.
+func (i *Item) ByID(id string) *Item {
+	if id == "" {
+		return nil
+	}
+
+	root := i.Root()
+	if root.index == nil {
+		return root.findID(id)
+	}
+
+	root.index.mu.RLock()
+	item, ok := root.index.ids[id]
+	root.index.mu.RUnlock()
+
+	if ok && item.ID == id && item.Root() == root {
+		return item
+	}
+	return nil
+}
+
+// SetID sets the ID of the item and updates the ID index of the menu tree it belongs to, see ByID.
+// It returns ErrDuplicateItemID if another item of the tree has the same ID, the ID being left unchanged.
+func (i *Item) SetID(id string) error {
+	root := i.Root()
+	if root.index == nil {
+		i.ID = id
+		return nil
+	}
+
+	root.index.mu.Lock()
+	defer root.index.mu.Unlock()
+
+	if other, ok := root.index.ids[id]; ok && id != "" && other != i && other.ID == id && other.Root() == root {
+		return fmt.Errorf("%w: %q", ErrDuplicateItemID, id)
+	}
+	if root.index.ids[i.ID] == i {
+		delete(root.index.ids, i.ID)
+	}
+	i.ID = id
+	if id != "" {
+		root.index.ids[id] = i
+	}
+	return nil
+}
+
+// Reindex rebuilds the ID index of the menu tree the item belongs to.
+// It returns ErrDuplicateItemID if two items share the same ID.
+func (i *Item) Reindex() error {
+	root := i.Root()
+
+	ids := map[string]*Item{}
+	if err := root.collectIDs(ids); err != nil {
+		return err
+	}
+
+	if root.index == nil {
+		root.index = newIDIndex()
+	}
+
+	root.index.mu.Lock()
+	defer root.index.mu.Unlock()
+
+	root.index.ids = ids
+	return nil
+}
+
+// indexSubtree registers the IDs of the child subtree in the index of the root of i.
+func (i *Item) indexSubtree(child *Item) error {
+	root := i.Root()
+	if root.index == nil {
+		root.index = newIDIndex()
+	}
+
+	ids := map[string]*Item{}
+	if err := child.collectIDs(ids); err != nil {
+		return err
+	}
+
+	root.index.mu.Lock()
+	defer root.index.mu.Unlock()
+
+	for id, item := range ids {
+		if other, ok := root.index.ids[id]; ok && other != item && other.ID == id && other.Root() == root {
+			return fmt.Errorf("%w: %q", ErrDuplicateItemID, id)
+		}
+	}
+	for id, item := range ids {
+		root.index.ids[id] = item
+	}
+	child.index = nil
+
+	return nil
+}
+
+// unindexSubtree removes the IDs of the child subtree from the index of the root of i.
+func (i *Item) unindexSubtree(child *Item) {
+	root := i.Root()
+	if root.index == nil {
+		return
+	}
+
+	ids := map[string]*Item{}
+	_ = child.collectIDs(ids)
+
+	root.index.mu.Lock()
+	defer root.index.mu.Unlock()
+
+	for id, item := range ids {
+		if root.index.ids[id] == item {
+			delete(root.index.ids, id)
+		}
+	}
+}
+
+func (i *Item) collectIDs(ids map[string]*Item) error {
+	if i.ID != "" {
+		if other, ok := ids[i.ID]; ok && other != i {
+			return fmt.Errorf("%w: %q", ErrDuplicateItemID, i.ID)
+		}
+		ids[i.ID] = i
+	}
+	for _, child := range i.Children {
+		if err := child.collectIDs(ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Item) findID(id string) *Item {
+	for item := range i.All() {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
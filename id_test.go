@@ -0,0 +1,91 @@
+package menu
+
+import (
+	"errors"
+	"testing"
+)
+
+func idTree(t *testing.T) (root, blog, post *Item) {
+	t.Helper()
+
+	root, err := NewItem("root", WithID("root"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blog, _ = root.AddChild("blog", WithID("blog"))
+	post, _ = blog.AddChild("post", WithID("post"))
+	return root, blog, post
+}
+
+func TestByIDAfterCopy(t *testing.T) {
+	root, _, post := idTree(t)
+
+	c, err := root.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := c.ByID("post")
+	if found == nil || found == post || found.Root() != c {
+		t.Errorf("copy.ByID(post) = %p, want the copied post, original %p", found, post)
+	}
+	if root.ByID("post") != post {
+		t.Error("ByID(post) on the original does not return the original post")
+	}
+
+	if _, err = root.AddChild(c); !errors.Is(err, ErrDuplicateItemID) {
+		t.Errorf("AddChild(copy) error = %v, want ErrDuplicateItemID", err)
+	}
+}
+
+func TestByIDAfterRemoveChild(t *testing.T) {
+	root, blog, post := idTree(t)
+
+	if !root.RemoveChild(blog) {
+		t.Fatal("RemoveChild() = false")
+	}
+	if item := root.ByID("post"); item != nil {
+		t.Errorf("ByID(post) = %s after removing its branch, want nil", item.Name)
+	}
+	if blog.ByID("post") != post {
+		t.Error("ByID(post) on the removed branch does not return post")
+	}
+
+	if _, err := root.AddChild(blog); err != nil {
+		t.Fatal(err)
+	}
+	if root.ByID("post") != post {
+		t.Error("ByID(post) does not return post after adding its branch back")
+	}
+}
+
+func TestByIDAfterSetID(t *testing.T) {
+	root, blog, post := idTree(t)
+
+	if err := post.SetID("article"); err != nil {
+		t.Fatal(err)
+	}
+	if item := root.ByID("post"); item != nil {
+		t.Errorf("ByID(post) = %s after SetID, want nil", item.Name)
+	}
+	if root.ByID("article") != post {
+		t.Error("ByID(article) does not return post")
+	}
+
+	if err := post.SetID("blog"); !errors.Is(err, ErrDuplicateItemID) {
+		t.Errorf("SetID(blog) error = %v, want ErrDuplicateItemID", err)
+	}
+	if post.ID != "article" || root.ByID("blog") != blog {
+		t.Errorf("SetID(blog) changed the IDs: post.ID = %q", post.ID)
+	}
+
+	post.ID = "raw"
+	if item := root.ByID("raw"); item != nil {
+		t.Errorf("ByID(raw) = %s before Reindex, want nil", item.Name)
+	}
+	if err := root.Reindex(); err != nil {
+		t.Fatal(err)
+	}
+	if root.ByID("raw") != post {
+		t.Error("ByID(raw) does not return post after Reindex")
+	}
+}
@@ -0,0 +1,71 @@
+package menu
+
+import "iter"
+
+// KeywordsExtra holds the search keywords of an item, exported by FlattenForIndex.
+var KeywordsExtra = DefineExtra("menu", ExtraKey("menu", "keywords"), []string(nil), "keywords")
+
+// WithKeywords is a function that returns an Option for setting the "menu.keywords" extra of an Item.
+func WithKeywords(keywords ...string) Option {
+	return KeywordsExtra.Option(keywords)
+}
+
+// IndexRecord is a searchable record of a menu item, e.g. a document fed to a site search index.
+type IndexRecord struct {
+	ID       string   `json:"id,omitempty"`
+	Key      string   `json:"key"`
+	Path     []string `json:"path"`
+	Label    string   `json:"label"`
+	URI      string   `json:"uri"`
+	Level    int      `json:"level"`
+	Keywords []string `json:"keywords,omitempty"`
+	// Trail holds the labels of the ancestors of the item below the root, e.g. ["Blog", "2024"].
+	Trail []string `json:"trail,omitempty"`
+}
+
+// FlattenForIndex returns the records of the items of the menu in depth-first order, so navigation labels
+// can be fed to site search indexes. Only the items with a URI are exported, the hidden items and their
+// descendants are skipped, as well as the root itself.
+func FlattenForIndex(root *Item) []IndexRecord {
+	return flattenForIndex(root, root.All())
+}
+
+// FlattenForIndexBreadthFirst returns the records of FlattenForIndex in breadth-first order,
+// the items of the first levels first.
+func FlattenForIndexBreadthFirst(root *Item) []IndexRecord {
+	return flattenForIndex(root, root.BreadthFirst())
+}
+
+func flattenForIndex(root *Item, items iter.Seq[*Item]) []IndexRecord {
+	var records []IndexRecord
+	for item := range items {
+		if item == root || item.URI == "" || !indexable(root, item) {
+			continue
+		}
+
+		record := IndexRecord{
+			ID:       item.ID,
+			Key:      item.Key(),
+			Path:     item.Path(),
+			Label:    item.Label,
+			URI:      item.URI,
+			Level:    item.Level() - root.Level(),
+			Keywords: KeywordsExtra.Get(item),
+		}
+		for ancestor := item.Parent; ancestor != nil && ancestor != root; ancestor = ancestor.Parent {
+			record.Trail = append([]string{ancestor.Label}, record.Trail...)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// indexable checks that the item and its ancestors below the root are displayed, as well as their children.
+func indexable(root, item *Item) bool {
+	for ; item != nil && item != root; item = item.Parent {
+		if !item.Display || item.Parent != nil && !item.Parent.DisplayChildren {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+// Package benchtree builds menu trees of various shapes used by the benchmarks.
+package benchtree
+
+import (
+	"fmt"
+
+	"github.com/gowool/menu"
+)
+
+// CurrentURI is the URI of the item marked as current in every generated tree.
+const CurrentURI = "/current"
+
+// Shape is a named menu tree generator.
+type Shape struct {
+	Name  string
+	Build func() *menu.Item
+}
+
+// Shapes returns the trees the benchmarks run against: a wide flat menu,
+// a single deep branch and a balanced tree with a typical navbar fan-out.
+func Shapes() []Shape {
+	return []Shape{
+		{Name: "flat-1000", Build: func() *menu.Item { return Flat(1000) }},
+		{Name: "deep-50", Build: func() *menu.Item { return Deep(50) }},
+		{Name: "balanced-4x6", Build: func() *menu.Item { return Balanced(4, 6) }},
+	}
+}
+
+// Flat returns a root with n leaf children. The last child is the current item.
+func Flat(n int) *menu.Item {
+	root := menu.Must(menu.NewItem("root"))
+	for i := 0; i < n; i++ {
+		child := menu.Must(root.AddChild(fmt.Sprintf("item-%d", i), menu.WithLabel(fmt.Sprintf("Item %d", i))))
+		child.URI = fmt.Sprintf("/item-%d", i)
+	}
+	if n > 0 {
+		root.LastChild().URI = CurrentURI
+	}
+	return root
+}
+
+// Deep returns a chain of depth nested items. The deepest item is the current item.
+func Deep(depth int) *menu.Item {
+	root := menu.Must(menu.NewItem("root"))
+	parent := root
+	for i := 0; i < depth; i++ {
+		parent = menu.Must(parent.AddChild(fmt.Sprintf("level-%d", i),
+			menu.WithLabel(fmt.Sprintf("Level %d", i)),
+			menu.WithURI(fmt.Sprintf("/level-%d", i)),
+		))
+	}
+	parent.URI = CurrentURI
+	return root
+}
+
+// Balanced returns a tree of the given depth where every branch has breadth children.
+// The last leaf is the current item.
+func Balanced(depth, breadth int) *menu.Item {
+	root := menu.Must(menu.NewItem("root"))
+	last := balanced(root, "", depth, breadth)
+	last.URI = CurrentURI
+	return root
+}
+
+func balanced(parent *menu.Item, prefix string, depth, breadth int) *menu.Item {
+	if depth == 0 {
+		return parent
+	}
+
+	var last *menu.Item
+	for i := 0; i < breadth; i++ {
+		name := fmt.Sprintf("%s/%d", prefix, i)
+		child := menu.Must(parent.AddChild(name, menu.WithLabel(name), menu.WithURI(name)))
+		last = balanced(child, name, depth-1, breadth)
+	}
+	return last
+}
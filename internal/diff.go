@@ -0,0 +1,55 @@
+package internal
+
+import "strings"
+
+// DiffLines returns a line diff between a and b, using "-" and "+" prefixes for removed and added lines
+// and "  " for unchanged ones. It uses a longest common subsequence table, which is fine for development
+// tooling but quadratic in the number of lines.
+func DiffLines(a, b []string) []string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, "- "+a[i])
+			i++
+		default:
+			diff = append(diff, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, "+ "+b[j])
+	}
+	return diff
+}
+
+// HTMLLines splits HTML into lines, breaking after every tag when the markup is on a single line.
+func HTMLLines(s string) []string {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "\n") {
+		s = strings.ReplaceAll(s, ">", ">\n")
+	}
+	return strings.Split(strings.TrimSpace(s), "\n")
+}
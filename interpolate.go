@@ -0,0 +1,130 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// InterpolateExtra marks the label of an item as a template resolved at render time, see WithInterpolation.
+var InterpolateExtra = DefineExtra("menu", ExtraKey("menu", "interpolate"), false, "interpolate")
+
+// WithInterpolation is a function that returns an Option marking the label of an Item as a template,
+// e.g. "Hello, {user.name}", whose variables are resolved at render time, see Item.InterpolateLabel.
+func WithInterpolation() Option {
+	return InterpolateExtra.Option(true)
+}
+
+type varsKey struct{}
+
+// ContextWithVars returns a copy of the context carrying the variables of the label templates,
+// merged over the variables already carried by the context.
+func ContextWithVars(ctx context.Context, vars map[string]any) context.Context {
+	merged := maps.Clone(VarsFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]any, len(vars))
+	}
+	maps.Copy(merged, vars)
+	return context.WithValue(ctx, varsKey{}, merged)
+}
+
+// VarsFromContext returns the variables of the label templates carried by the context, or nil.
+func VarsFromContext(ctx context.Context) map[string]any {
+	vars, _ := ctx.Value(varsKey{}).(map[string]any)
+	return vars
+}
+
+// InterpolateLabel returns the label of the item with its variables resolved when the item has the
+// interpolate extra, and the label as is otherwise. The resolved values are passed to escape, if not nil,
+// so they can be escaped independently of the label, e.g. for safe labels holding HTML.
+func (i *Item) InterpolateLabel(ctx context.Context, escape func(string) string) string {
+	if !InterpolateExtra.Get(i) {
+		return i.Label
+	}
+	return Interpolate(ctx, i, i.Label, escape)
+}
+
+// Interpolate resolves the variables of the template s, written in braces, e.g. "Hello, {user.name}".
+// "{{" stands for a literal brace. A variable is resolved, in order:
+//   - from the extra of the item with the same key, e.g. {seo.priority};
+//   - from the variables carried by the context, see ContextWithVars, with the same key;
+//   - by walking the nested maps of the context variables along the dot separated key, e.g. {user.name}
+//     resolves vars["user"]["name"].
+//
+// Unknown variables are replaced by an empty string. The resolved values are formatted with fmt
+// and passed to escape, if not nil.
+func Interpolate(ctx context.Context, item *Item, s string, escape func(string) string) string {
+	if !strings.Contains(s, "{") {
+		return s
+	}
+
+	vars := VarsFromContext(ctx)
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			break
+		}
+		b.WriteString(s[:start])
+		s = s[start+1:]
+
+		if strings.HasPrefix(s, "{") {
+			b.WriteByte('{')
+			s = s[1:]
+			continue
+		}
+
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			// unterminated variable, kept as is
+			b.WriteByte('{')
+			break
+		}
+
+		if value, ok := lookupVar(item, vars, strings.TrimSpace(s[:end])); ok && value != nil {
+			text := fmt.Sprint(value)
+			if escape != nil {
+				text = escape(text)
+			}
+			b.WriteString(text)
+		}
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+
+	return b.String()
+}
+
+func lookupVar(item *Item, vars map[string]any, key string) (any, bool) {
+	if item != nil {
+		if value, ok := item.Extras[key]; ok {
+			return value, true
+		}
+	}
+	if value, ok := vars[key]; ok {
+		return value, true
+	}
+
+	var current any = vars
+	for _, name := range strings.Split(key, ".") {
+		switch m := current.(type) {
+		case map[string]any:
+			value, ok := m[name]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case map[string]string:
+			value, ok := m[name]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
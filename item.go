@@ -3,27 +3,37 @@ package menu
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
+	"strings"
 )
 
 var ErrItemBelongsToAnotherMenu = errors.New("cannot add menu item as child, it already belongs to another menu (e.g. has a parent)")
 
 // Item represents an item in a menu.
+//
+// Items can be round-tripped through JSON: the Parent pointers are not encoded, they are rebuilt
+// from the children when decoding. Display and DisplayChildren are always encoded, a missing
+// field decodes as true, as NewItem and the loaders default them.
 type Item struct {
+	ID                 string         `json:"id,omitempty"`
 	Name               string         `json:"name,omitempty"`
 	URI                string         `json:"uri,omitempty"`
 	Label              string         `json:"label,omitempty"`
 	Position           int            `json:"position,omitempty"`
-	DisplayChildren    bool           `json:"display_children,omitempty"`
-	Display            bool           `json:"display,omitempty"`
+	DisplayChildren    bool           `json:"display_children"`
+	Display            bool           `json:"display"`
 	Current            *bool          `json:"current,omitempty"`
 	Attributes         map[string]any `json:"attributes,omitempty"`
 	LinkAttributes     map[string]any `json:"link_attributes,omitempty"`
 	ChildrenAttributes map[string]any `json:"children_attributes,omitempty"`
 	LabelAttributes    map[string]any `json:"label_attributes,omitempty"`
 	Extras             map[string]any `json:"extras,omitempty"`
-	Parent             *Item          `json:"parent,omitempty"`
+	Parent             *Item          `json:"-"`
 	Children           []*Item        `json:"children,omitempty"`
+
+	index       *idIndex
+	uriPatterns *compiledURIPatterns
 }
 
 func Must(item *Item, err error) *Item {
@@ -46,6 +56,7 @@ func NewItem(name string, options ...Option) (*Item, error) {
 		Extras:             map[string]any{},
 		Display:            true,
 		DisplayChildren:    true,
+		index:              newIDIndex(),
 	}
 
 	for _, option := range options {
@@ -54,6 +65,10 @@ func NewItem(name string, options ...Option) (*Item, error) {
 		}
 	}
 
+	if item.ID != "" {
+		item.index.ids[item.ID] = item
+	}
+
 	return item, nil
 }
 
@@ -120,10 +135,19 @@ func (i *Item) LabelAttribute(name string, def any) any {
 
 // Extra returns the value of the specified extra info for an Item.
 // If the info is not found, it returns the default value provided or nil.
+// The extras declared in DefaultExtras are also found by their key or their aliases, e.g. "badge" for "menu.badge".
+// Built-in extras should be read with their typed accessors, e.g. SafeLabelExtra.Get(item).
 func (i *Item) Extra(name string, def ...any) any {
 	if extra, ok := i.Extras[name]; ok {
 		return extra
 	}
+	if info, ok := DefaultExtras.Lookup(name); ok {
+		for _, key := range append([]string{info.Key}, info.Aliases...) {
+			if extra, ok := i.Extras[key]; ok {
+				return extra
+			}
+		}
+	}
 	if len(def) > 0 {
 		return def[0]
 	}
@@ -154,11 +178,66 @@ func (i *Item) Level() int {
 	return i.Parent.Level() + 1
 }
 
-// Copy creates a deep copy of the Item and its children.
+// Path returns the names of the items from the root down to the item, e.g. ["root", "blog", "article1"].
+// Unlike pointers, paths can be used to reference items stably in logs, errors and APIs.
+func (i *Item) Path() []string {
+	if i.Parent == nil {
+		return []string{i.Name}
+	}
+	return append(i.Parent.Path(), i.Name)
+}
+
+// PathString returns the path of the item joined with the separator, e.g. "root/blog/article1".
+func (i *Item) PathString(sep string) string {
+	return strings.Join(i.Path(), sep)
+}
+
+// Key returns a stable key of the item, e.g. to persist client side state across navigations:
+// its ID if set, its path joined with "/" otherwise.
+func (i *Item) Key() string {
+	if i.ID != "" {
+		return i.ID
+	}
+	return i.PathString("/")
+}
+
+// ItemAt returns the item addressed by the path relative to the current item, as returned by Path.
+// The first element of the path must be the name of the current item, so root.ItemAt(item.Path())
+// resolves the item itself. If no item matches the path, nil is returned.
+func (i *Item) ItemAt(path []string) *Item {
+	if len(path) == 0 || path[0] != i.Name {
+		return nil
+	}
+
+	item := i
+	for _, name := range path[1:] {
+		if item = item.Child(name); item == nil {
+			return nil
+		}
+	}
+	return item
+}
+
+// Copy creates a deep copy of the Item and its children. The attributes, the extras and the Current flag
+// are copied too, so the copy can be modified without changing the original, the values of the maps are shared.
 func (i *Item) Copy() (*Item, error) {
 	item := *i
 	item.Parent = nil
 	item.Children = make([]*Item, 0, len(i.Children))
+	item.index = newIDIndex()
+	item.Attributes = maps.Clone(i.Attributes)
+	item.LinkAttributes = maps.Clone(i.LinkAttributes)
+	item.ChildrenAttributes = maps.Clone(i.ChildrenAttributes)
+	item.LabelAttributes = maps.Clone(i.LabelAttributes)
+	item.Extras = maps.Clone(i.Extras)
+	if i.Current != nil {
+		current := *i.Current
+		item.Current = &current
+	}
+
+	if item.ID != "" {
+		item.index.ids[item.ID] = &item
+	}
 
 	for _, child := range i.Children {
 		c, err := child.Copy()
@@ -173,6 +252,26 @@ func (i *Item) Copy() (*Item, error) {
 	return &item, nil
 }
 
+// Slice creates a deep copy of the Item holding only length of its children from the offset, e.g. to split
+// a long menu into columns or to render its first items only. A negative length keeps the children up to
+// the last one, and the bounds out of range are clamped to the children.
+//
+// Example usage:
+//
+//	first, err := root.Slice(0, 5)
+//	rest, err := root.Slice(5, -1)
+func (i *Item) Slice(offset, length int) (*Item, error) {
+	offset = min(max(offset, 0), len(i.Children))
+	end := len(i.Children)
+	if length >= 0 {
+		end = min(offset+length, end)
+	}
+
+	item := *i
+	item.Children = i.Children[offset:end]
+	return item.Copy()
+}
+
 // AddChild adds a child item to the current item. It accepts a `child` parameter of type `any`,
 // which can be either an `*Item` or any other value. If `child` is an `*Item`, it checks if the child already
 // belongs to another menu (i.e., it has a non-nil parent). If so, it returns an error `ErrItemBelongsToAnotherMenu`.
@@ -180,6 +279,8 @@ func (i *Item) Copy() (*Item, error) {
 // If `child` is not an `*Item`, it creates a new item with a name obtained by formatting `child` as a string
 // and using the options passed as variadic arguments. It sets the parent of the newly created child to the current item
 // and appends it to the list of children. The method returns the child item added and a possible error.
+// The IDs of the child subtree are registered in the index of the root, ErrDuplicateItemID is returned
+// if one of them is already used in the menu.
 func (i *Item) AddChild(child any, options ...Option) (childItem *Item, err error) {
 	switch child := child.(type) {
 	case *Item:
@@ -194,6 +295,10 @@ func (i *Item) AddChild(child any, options ...Option) (childItem *Item, err erro
 		}
 	}
 
+	if err = i.indexSubtree(childItem); err != nil {
+		return nil, err
+	}
+
 	childItem.Parent = i
 	i.Children = append(i.Children, childItem)
 
@@ -210,6 +315,62 @@ func (i *Item) Child(name string) *Item {
 	return nil
 }
 
+// Find returns the first item of the subtree, the item itself included, matching the predicate
+// in depth-first order. If no item matches, nil is returned.
+//
+// Example usage:
+//
+//	blog := root.Find(func(item *Item) bool { return item.URI == "/blog" })
+func (i *Item) Find(predicate func(*Item) bool) *Item {
+	for item := range i.All() {
+		if predicate(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// FindAll returns all the items of the subtree, the item itself included, matching the predicate
+// in depth-first order.
+func (i *Item) FindAll(predicate func(*Item) bool) []*Item {
+	var items []*Item
+	for item := range i.All() {
+		if predicate(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// RemoveChild detaches the child from the item: it is removed from the children, its parent pointer is cleared
+// and its subtree is removed from the ID index of the menu. It returns false if the child does not belong to the item.
+func (i *Item) RemoveChild(child *Item) bool {
+	if child == nil || child.Parent != i {
+		return false
+	}
+
+	index := slices.Index(i.Children, child)
+	if index < 0 {
+		return false
+	}
+
+	i.unindexSubtree(child)
+	i.Children = slices.Delete(i.Children, index, index+1)
+	child.Parent = nil
+
+	return true
+}
+
+// RemoveChildByName detaches the first child with the given name, see RemoveChild.
+// It returns the removed child, or nil if no child has the name.
+func (i *Item) RemoveChildByName(name string) *Item {
+	child := i.Child(name)
+	if child == nil || !i.RemoveChild(child) {
+		return nil
+	}
+	return child
+}
+
 // ReorderChildren sorts the child items of an Item based on their Position field.
 // The sorting is done in ascending order.
 func (i *Item) ReorderChildren() {
@@ -0,0 +1,51 @@
+package menu
+
+import "testing"
+
+func TestItemCopyIsIndependent(t *testing.T) {
+	root, err := NewItem("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := root.AddChild("child",
+		WithAttributes(map[string]any{"class": "item"}),
+		WithLinkAttributes(map[string]any{"rel": "nofollow"}),
+		WithChildrenAttributes(map[string]any{"class": "list"}),
+		WithLabelAttributes(map[string]any{"class": "label"}),
+		WithExtra("note", "original"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child.SetIsCurrent()
+
+	cp, err := root.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cp.Children[0]
+	c.Attributes["class"] = "mutated"
+	c.LinkAttributes["rel"] = "mutated"
+	c.ChildrenAttributes["class"] = "mutated"
+	c.LabelAttributes["class"] = "mutated"
+	c.Extras["note"] = "mutated"
+	*c.Current = false
+
+	for name, value := range map[string]any{
+		"Attributes":         child.Attributes["class"],
+		"LinkAttributes":     child.LinkAttributes["rel"],
+		"ChildrenAttributes": child.ChildrenAttributes["class"],
+		"LabelAttributes":    child.LabelAttributes["class"],
+		"Extras":             child.Extras["note"],
+	} {
+		if value == "mutated" {
+			t.Errorf("modifying the %s of the copy changed the original", name)
+		}
+	}
+	if !*child.Current {
+		t.Errorf("modifying the Current flag of the copy changed the original")
+	}
+	if c.Parent != cp {
+		t.Errorf("the copied child has the parent %v, want the copied root", c.Parent)
+	}
+}
@@ -0,0 +1,67 @@
+package menu
+
+import "iter"
+
+// All returns an iterator over the item and its descendants in depth-first pre-order.
+//
+// Example usage:
+//
+//	for item := range root.All() {
+//	    fmt.Println(item.PathString("/"))
+//	}
+func (i *Item) All() iter.Seq[*Item] {
+	return func(yield func(*Item) bool) {
+		i.walk(yield)
+	}
+}
+
+// Descendants returns an iterator over the descendants of the item in depth-first pre-order, the item excluded.
+func (i *Item) Descendants() iter.Seq[*Item] {
+	return func(yield func(*Item) bool) {
+		for _, child := range i.Children {
+			if !child.walk(yield) {
+				return
+			}
+		}
+	}
+}
+
+// BreadthFirst returns an iterator over the item and its descendants in breadth-first order,
+// level by level.
+func (i *Item) BreadthFirst() iter.Seq[*Item] {
+	return func(yield func(*Item) bool) {
+		queue := []*Item{i}
+		for len(queue) > 0 {
+			item := queue[0]
+			queue = queue[1:]
+			if !yield(item) {
+				return
+			}
+			queue = append(queue, item.Children...)
+		}
+	}
+}
+
+// Ancestors returns an iterator over the ancestors of the item, from its parent up to the root.
+func (i *Item) Ancestors() iter.Seq[*Item] {
+	return func(yield func(*Item) bool) {
+		for parent := i.Parent; parent != nil; parent = parent.Parent {
+			if !yield(parent) {
+				return
+			}
+		}
+	}
+}
+
+// walk calls yield for the item and its descendants in depth-first pre-order until yield returns false.
+func (i *Item) walk(yield func(*Item) bool) bool {
+	if !yield(i) {
+		return false
+	}
+	for _, child := range i.Children {
+		if !child.walk(yield) {
+			return false
+		}
+	}
+	return true
+}
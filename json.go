@@ -0,0 +1,66 @@
+package menu
+
+import "encoding/json"
+
+// UnmarshalJSON decodes an item and its children. It rebuilds the Parent pointers of the children,
+// defaults Display and DisplayChildren to true when the JSON document omits them, initializes the attribute maps left empty by the JSON document, converts the declared extras to their types,
+// see ExtrasRegistry.Decode, and rebuilds the ID index of the tree once the whole tree is decoded.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	if err := decodeItem(data, i); err != nil {
+		return err
+	}
+
+	i.index = nil
+	return i.Reindex()
+}
+
+// itemDecoder decodes the children of an item without indexing them, the tree being indexed once by UnmarshalJSON.
+type itemDecoder struct {
+	item *Item
+}
+
+func (d *itemDecoder) UnmarshalJSON(data []byte) error {
+	d.item = &Item{}
+	return decodeItem(data, d.item)
+}
+
+// decodeItem decodes an item and its children into i, see UnmarshalJSON, without indexing them.
+func decodeItem(data []byte, i *Item) error {
+	type item Item
+
+	*i = Item{Display: true, DisplayChildren: true}
+	decoded := struct {
+		*item
+		Children []itemDecoder `json:"children,omitempty"`
+	}{item: (*item)(i)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	i.Parent = nil
+	i.Children = nil
+	for _, child := range decoded.Children {
+		child.item.Parent = i
+		i.Children = append(i.Children, child.item)
+	}
+
+	if i.Attributes == nil {
+		i.Attributes = map[string]any{}
+	}
+	if i.LinkAttributes == nil {
+		i.LinkAttributes = map[string]any{}
+	}
+	if i.ChildrenAttributes == nil {
+		i.ChildrenAttributes = map[string]any{}
+	}
+	if i.LabelAttributes == nil {
+		i.LabelAttributes = map[string]any{}
+	}
+	if i.Extras == nil {
+		i.Extras = map[string]any{}
+	}
+	DefaultExtras.Decode(i.Extras)
+	i.compileURIPatterns()
+
+	return nil
+}
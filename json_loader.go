@@ -0,0 +1,103 @@
+package menu
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+var _ Loader = JSONLoader{}
+
+// JSONLoader is a Loader building menus from JSON documents describing a nested ItemConfig, e.g.
+//
+//	{
+//	  "name": "main",
+//	  "children": [
+//	    {"name": "home", "label": "Home", "uri": "/"},
+//	    {"name": "blog", "label": "Blog", "uri": "/blog", "position": 10, "attributes": {"class": "blog"}, "children": [...]}
+//	  ]
+//	}
+//
+// The data can be a []byte, a json.RawMessage, a string or an io.Reader. Children keep the order of the document.
+type JSONLoader struct{}
+
+// NewJSONLoader returns a new instance of JSONLoader.
+func NewJSONLoader() JSONLoader {
+	return JSONLoader{}
+}
+
+// Load decodes the JSON document and builds the menu it describes.
+// Malformed documents and children without a name are reported with ErrInvalidConfig.
+func (l JSONLoader) Load(_ context.Context, data any) (*Item, error) {
+	var r io.Reader
+	switch data := data.(type) {
+	case []byte:
+		r = bytes.NewReader(data)
+	case json.RawMessage:
+		r = bytes.NewReader(data)
+	case string:
+		r = bytes.NewReader([]byte(data))
+	case io.Reader:
+		r = data
+	default:
+		return nil, fmt.Errorf("%w: expected []byte, json.RawMessage, string or io.Reader, got %T", ErrUnsupported, data)
+	}
+
+	var cfg ItemConfig
+	if err := json.NewDecoder(r).Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if err := cfg.validate(cfg.Name); err != nil {
+		return nil, err
+	}
+
+	return cfg.Item()
+}
+
+// Supports checks if the given data is a json.RawMessage, or a []byte, a string or a *bufio.Reader holding
+// a JSON object, i.e. starting with "{" after the leading white space, so the loaders registered after it
+// in a ChainLoader, e.g. for YAML documents, still receive their data. Other readers cannot be inspected without
+// consuming them and are not claimed, but Load accepts them, and ChainLoader.Load buffers them for Supports.
+func (l JSONLoader) Supports(data any) bool {
+	switch data := data.(type) {
+	case json.RawMessage:
+		return true
+	case []byte:
+		return isJSONObject(data)
+	case string:
+		return isJSONObject([]byte(data))
+	case *bufio.Reader:
+		return peekJSONObject(data)
+	default:
+		return false
+	}
+}
+
+// isJSONObject checks if the first non-space character of the data opens a JSON object.
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '{'
+}
+
+// peekJSONObject checks if the first non-space character of the reader opens a JSON object, without consuming it.
+// Only the white space fitting in the buffer of the reader is skipped.
+func peekJSONObject(r *bufio.Reader) bool {
+	for n := 1; n <= r.Size(); n++ {
+		data, _ := r.Peek(n)
+		if len(data) < n {
+			return false
+		}
+		switch data[n-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
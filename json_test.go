@@ -0,0 +1,60 @@
+package menu
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshalJSONDisplayDefaults(t *testing.T) {
+	var root Item
+	if err := json.Unmarshal([]byte(`{"name":"root","children":[{"name":"x","uri":"/x"},{"name":"y","display":false,"display_children":false}]}`), &root); err != nil {
+		t.Fatal(err)
+	}
+
+	x, y := root.Child("x"), root.Child("y")
+	if !root.Display || !root.DisplayChildren || !x.Display || !x.DisplayChildren {
+		t.Error("Display and DisplayChildren are not true when the document omits them")
+	}
+	if y.Display || y.DisplayChildren {
+		t.Error("Display and DisplayChildren are not false when the document sets them to false")
+	}
+}
+
+func TestItemJSONRoundTrip(t *testing.T) {
+	root, err := NewItem("root", WithID("root"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blog, _ := root.AddChild("blog", WithID("blog"), WithURI("/blog"), WithDisplay(false))
+	_, _ = blog.AddChild("post", WithID("post"), WithURI("/blog/post"), WithAttribute("class", "post"))
+
+	data, err := json.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Item
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Parent != nil {
+		t.Error("the decoded root has a parent")
+	}
+	b := decoded.Child("blog")
+	if b == nil || b.Parent != &decoded || b.Display {
+		t.Fatalf("blog = %+v, want a hidden child of the decoded root", b)
+	}
+	post := b.Child("post")
+	if post == nil || post.Parent != b || post.Attributes["class"] != "post" {
+		t.Fatalf("post = %+v, want a child of blog with its attributes", post)
+	}
+
+	for id, want := range map[string]*Item{"root": &decoded, "blog": b, "post": post} {
+		if got := decoded.ByID(id); got != want {
+			t.Errorf("ByID(%q) = %v, want %v", id, got, want)
+		}
+	}
+	if post.ByID("root") != &decoded {
+		t.Error("ByID(root) from a decoded child does not return the decoded root")
+	}
+}
@@ -0,0 +1,76 @@
+package menu
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var _ Voter = LocaleVoter{}
+
+// DefaultLocalePattern matches the path segments used as locale prefixes by LocaleVoter by default,
+// e.g. "de", "pt-BR" or "zh_TW". Prefer listing the locales of the application in LocaleVoter.Locales,
+// so that a two-letter section such as /go is not mistaken for a locale.
+var DefaultLocalePattern = regexp.MustCompile(`^[a-z]{2}(?:[-_][A-Za-z]{2})?$`)
+
+// LocaleVoter is a Voter comparing the path of the request URL with the URI of the items like URLVoter does,
+// after stripping their locale prefix, so that localized routes such as /de/blog still match the item /blog.
+// When both the request path and the item URI have a locale prefix, they only match if the locales are the same.
+// The URI patterns of the item, see WithURIPattern, are matched with the request path with and without its prefix.
+//
+// Example usage:
+//
+//	matcher := menu.NewCoreMatcher(menu.LocaleVoter{Locales: []string{"en", "de", "fr"}})
+type LocaleVoter struct {
+	// Locales holds the path segments stripped as locale prefixes.
+	Locales []string
+	// Patterns holds the patterns of the path segments stripped as locale prefixes, in addition to Locales.
+	// When both are empty, DefaultLocalePattern is used.
+	Patterns []*regexp.Regexp
+}
+
+// MatchItem checks whether the request URL carried by the context, without its locale prefix,
+// matches the URI of the item without its locale prefix. It returns nil otherwise.
+func (v LocaleVoter) MatchItem(ctx context.Context, item *Item) *bool {
+	u, ok := RequestURL(ctx)
+	if !ok {
+		return nil
+	}
+
+	requestLocale, requestPath := v.Strip(u.Path)
+	if item.MatchesURIPattern(requestPath) || item.MatchesURIPattern(u.Path) {
+		return &ok
+	}
+
+	itemLocale, itemPath := v.Strip(item.URI)
+	if item.URI == "" || requestPath != itemPath || (requestLocale != "" && itemLocale != "" && requestLocale != itemLocale) {
+		return nil
+	}
+	return &ok
+}
+
+// Strip splits the locale prefix from the path, e.g. "/de/blog" returns "de" and "/blog".
+// The path is returned unchanged with an empty locale when its first segment is not a locale.
+func (v LocaleVoter) Strip(path string) (locale, rest string) {
+	segment, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !strings.HasPrefix(path, "/") || !v.isLocale(segment) {
+		return "", path
+	}
+	return segment, "/" + rest
+}
+
+func (v LocaleVoter) isLocale(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	if len(v.Locales) == 0 && len(v.Patterns) == 0 {
+		return DefaultLocalePattern.MatchString(segment)
+	}
+	if slices.Contains(v.Locales, segment) {
+		return true
+	}
+	return slices.ContainsFunc(v.Patterns, func(pattern *regexp.Regexp) bool {
+		return pattern.MatchString(segment)
+	})
+}
@@ -0,0 +1,142 @@
+package menu
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var _ Loader = MapLoader{}
+
+// ErrDuplicatePosition is returned by a strict MapLoader when siblings share the same position.
+var ErrDuplicatePosition = errors.New("menu item position already used by a sibling")
+
+// ItemDef defines an item of a map based menu definition, where the items are keyed by name.
+// Since Go maps are unordered, the order of the children is defined by their Position.
+type ItemDef struct {
+	Label    string
+	URI      string
+	Position int
+	Options  []Option
+	Children map[string]ItemDef
+}
+
+// MapLoader is a Loader building menus from map based definitions: an ItemDef describing the root
+// or a map[string]ItemDef describing its children.
+//
+// The traversal is deterministic: siblings are sorted by Position, then by name. In the default mode, children
+// without a position (0) are assigned the positions following the highest explicit position of their siblings,
+// in name order. In the strict mode, all the siblings must have distinct positions.
+type MapLoader struct {
+	root   string
+	strict bool
+}
+
+// NewMapLoader creates a new MapLoader naming the root items rootName.
+func NewMapLoader(rootName string) MapLoader {
+	return MapLoader{root: rootName}
+}
+
+// Strict returns a copy of the loader failing with ErrDuplicatePosition when siblings share the same position
+// instead of auto-assigning the missing positions.
+func (l MapLoader) Strict() MapLoader {
+	l.strict = true
+	return l
+}
+
+// Load builds the menu described by an ItemDef or a map[string]ItemDef.
+func (l MapLoader) Load(_ context.Context, data any) (*Item, error) {
+	var def ItemDef
+	switch data := data.(type) {
+	case ItemDef:
+		def = data
+	case map[string]ItemDef:
+		def = ItemDef{Children: data}
+	default:
+		return nil, fmt.Errorf("%w: expected ItemDef or map[string]ItemDef, got %T", ErrUnsupported, data)
+	}
+
+	return l.load(l.root, def)
+}
+
+// Supports checks if the data is an ItemDef or a map[string]ItemDef.
+func (l MapLoader) Supports(data any) bool {
+	switch data.(type) {
+	case ItemDef, map[string]ItemDef:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l MapLoader) load(name string, def ItemDef) (*Item, error) {
+	options := append([]Option{WithLabel(def.Label), WithURI(def.URI), WithPosition(def.Position)}, def.Options...)
+
+	item, err := NewItem(name, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	names, positions, err := l.order(item, def.Children)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, childName := range names {
+		childDef := def.Children[childName]
+		childDef.Position = positions[childName]
+
+		child, err := l.load(childName, childDef)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = item.AddChild(child); err != nil {
+			return nil, err
+		}
+	}
+
+	return item, nil
+}
+
+// order returns the names of the children sorted by position then name, and their effective positions.
+func (l MapLoader) order(parent *Item, children map[string]ItemDef) ([]string, map[string]int, error) {
+	names := make([]string, 0, len(children))
+	for name := range children {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	positions := make(map[string]int, len(children))
+	if l.strict {
+		used := map[int]string{}
+		for _, name := range names {
+			position := children[name].Position
+			if other, ok := used[position]; ok {
+				return nil, nil, fmt.Errorf("%w: %q and %q of %q have position %d", ErrDuplicatePosition, other, name, parent.PathString("/"), position)
+			}
+			used[position] = name
+			positions[name] = position
+		}
+	} else {
+		last := 0
+		for _, name := range names {
+			last = max(last, children[name].Position)
+		}
+		for _, name := range names {
+			if position := children[name].Position; position != 0 {
+				positions[name] = position
+			} else {
+				last++
+				positions[name] = last
+			}
+		}
+	}
+
+	slices.SortStableFunc(names, func(a, b string) int {
+		return cmp.Compare(positions[a], positions[b])
+	})
+
+	return names, positions, nil
+}
@@ -5,22 +5,52 @@ import (
 	"sync"
 )
 
-var _ Matcher = (*CoreMatcher)(nil)
+var _ CurrentItemsMatcher = (*CoreMatcher)(nil)
 
 // Matcher represents an interface for matching items.
 // It provides methods for checking whether an item is current or an ancestor.
 // It also provides a method for clearing the state of the matcher.
+//
+// Several items of a menu can be current at the same time, e.g. a page linked from two branches: every one of
+// them is current, and the ancestors of every one of them are ancestors, so each branch shows its own trail.
 type Matcher interface {
 	// IsCurrent checks whether an item is current
 	IsCurrent(ctx context.Context, item *Item) bool
 
-	// IsAncestor checks whether an item is the ancestor of a current item
+	// IsAncestor checks whether an item is the ancestor of a current item, up to depth levels below it
+	// when depth is not nil, whichever the current item is when several are. Implementations must not
+	// modify the depth.
 	IsAncestor(ctx context.Context, item *Item, depth *int) bool
 
 	// Clear clears the state of the matcher
 	Clear()
 }
 
+// CurrentItemsMatcher is a Matcher listing the current items of a menu, implemented by CoreMatcher and
+// ScopedMatcher. The CurrentItems function uses it when the matcher implements it.
+type CurrentItemsMatcher interface {
+	Matcher
+
+	// CurrentItems returns the items below the root that are current, in depth-first order,
+	// or nil if none is.
+	CurrentItems(ctx context.Context, root *Item) []*Item
+}
+
+type matcherKey struct{}
+
+// WithMatcher returns a copy of the context carrying the matcher of the request, so that nested helpers,
+// such as template functions and decorators, can obtain it with MatcherFromContext instead of receiving it
+// as an argument. The renderers attach their matcher to the context while rendering.
+func WithMatcher(ctx context.Context, matcher Matcher) context.Context {
+	return context.WithValue(ctx, matcherKey{}, matcher)
+}
+
+// MatcherFromContext returns the matcher carried by the context, see WithMatcher, or nil if there is none.
+func MatcherFromContext(ctx context.Context) Matcher {
+	matcher, _ := ctx.Value(matcherKey{}).(Matcher)
+	return matcher
+}
+
 // CoreMatcher represents a matcher that determines the current state of an item.
 type CoreMatcher struct {
 	voters []Voter
@@ -88,19 +118,34 @@ func (m *CoreMatcher) IsCurrent(ctx context.Context, item *Item) bool {
 }
 
 // IsAncestor checks whether the given item is an ancestor of any current item in the hierarchy, up to the specified depth.
-// If the depth is not nil, it first checks if the depth is zero. If it is, it returns false.
-// Then, it iterates over each child of the given item. If the child is a current item or an ancestor (recursive call to IsAncestor), it returns true.
-// If none of the children match the condition, it returns false.
+// If the depth is not nil, only the descendants up to depth levels below the item are considered, and a zero depth
+// returns false. The depth is read, never modified, so a pointer shared between sibling checks, such as
+// the MatchingDepth option of a renderer, yields the same classification for every sibling.
 func (m *CoreMatcher) IsAncestor(ctx context.Context, item *Item, depth *int) bool {
-	if depth != nil {
-		if *depth == 0 {
-			return false
-		}
-		*depth = *depth - 1
+	return isAncestor(ctx, m, item, depth)
+}
+
+// CurrentItems returns the items below the root that are current, in depth-first order, or nil if none is.
+func (m *CoreMatcher) CurrentItems(ctx context.Context, root *Item) []*Item {
+	return currentItems(ctx, m, root)
+}
+
+// isAncestor checks whether a descendant of the item, up to depth levels below it when depth is not nil,
+// is current according to the matcher. The depth is read, never modified.
+func isAncestor(ctx context.Context, matcher Matcher, item *Item, depth *int) bool {
+	if depth == nil {
+		return descendantIsCurrent(ctx, matcher, item, -1)
 	}
+	return descendantIsCurrent(ctx, matcher, item, *depth)
+}
 
+// descendantIsCurrent checks the descendants of the item up to depth levels, a negative depth means no limit.
+func descendantIsCurrent(ctx context.Context, matcher Matcher, item *Item, depth int) bool {
+	if depth == 0 {
+		return false
+	}
 	for _, child := range item.Children {
-		if m.IsCurrent(ctx, child) || m.IsAncestor(ctx, child, depth) {
+		if matcher.IsCurrent(ctx, child) || descendantIsCurrent(ctx, matcher, child, depth-1) {
 			return true
 		}
 	}
@@ -0,0 +1,64 @@
+package menu
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestMatcherIsAncestorSiblings(t *testing.T) {
+	root, err := NewItem("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := root.AddChild("a")
+	a1, _ := a.AddChild("a1")
+	_, _ = a1.AddChild("a11", WithURI("/page"))
+	b, _ := root.AddChild("b")
+	_, _ = b.AddChild("b1", WithURI("/page"))
+	c, _ := root.AddChild("c", WithURI("/c"))
+	siblings := []*Item{a, b, c}
+
+	orders := [][]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+
+	depth := func(d int) *int { return &d }
+	tests := []struct {
+		name  string
+		depth *int
+		want  []bool
+	}{
+		{name: "unlimited", depth: nil, want: []bool{true, true, false}},
+		{name: "zero", depth: depth(0), want: []bool{false, false, false}},
+		{name: "one", depth: depth(1), want: []bool{false, true, false}},
+		{name: "two", depth: depth(2), want: []bool{true, true, false}},
+	}
+
+	matchers := map[string]func() Matcher{
+		"core":   func() Matcher { return NewCoreMatcher(URLVoter{}) },
+		"scoped": func() Matcher { return NewScopedMatcher(URLVoter{}) },
+	}
+
+	for matcherName, newMatcher := range matchers {
+		for _, tt := range tests {
+			t.Run(matcherName+"/"+tt.name, func(t *testing.T) {
+				for _, order := range orders {
+					ctx := WithMatcherScope(WithRequestURL(context.Background(), &url.URL{Path: "/page"}))
+					matcher := newMatcher()
+
+					var before *int
+					if tt.depth != nil {
+						before = depth(*tt.depth)
+					}
+					for _, i := range order {
+						if got := matcher.IsAncestor(ctx, siblings[i], tt.depth); got != tt.want[i] {
+							t.Errorf("order %v: IsAncestor(%s) = %t, want %t", order, siblings[i].Name, got, tt.want[i])
+						}
+					}
+					if before != nil && *tt.depth != *before {
+						t.Errorf("order %v: IsAncestor modified the depth from %d to %d", order, *before, *tt.depth)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package menufx provides the constructors of the menu services for dependency injection containers, such as
+// go.uber.org/fx or google/wire: the registry, the provider, the matcher, the renderers and an HTTP handler,
+// plus the lifecycle hooks warming the menus on start. The constructors take their dependencies as arguments
+// and return the services, so they can be handed to fx.Provide as is; the package does not depend on any container.
+//
+// Example usage with fx:
+//
+//	fx.New(
+//		fx.Provide(menufx.Constructors()...),
+//		fx.Invoke(func(lc fx.Lifecycle, provider *menu.CachedProvider) {
+//			hook := menufx.NewWarmHook(provider)
+//			lc.Append(fx.Hook{OnStart: hook.OnStart, OnStop: hook.OnStop})
+//		}),
+//	)
+package menufx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer"
+)
+
+// Constructors returns the constructors of the package, for fx.Provide:
+// NewRegistry, NewProvider, NewMatcher, NewRenderer and NewHandler.
+func Constructors() []any {
+	return []any{
+		NewRegistry,
+		NewProvider,
+		NewMatcher,
+		NewRenderer,
+		NewHandler,
+	}
+}
+
+// NewRegistry returns menu.DefaultRegistry, holding the builders, extensions and modules registered
+// by the init functions of the packages of the application.
+func NewRegistry() *menu.Registry {
+	return menu.DefaultRegistry()
+}
+
+// NewProvider returns a menu.CachedProvider caching the menus built by the registry.
+func NewProvider(registry *menu.Registry) *menu.CachedProvider {
+	return menu.NewCachedProvider(registry)
+}
+
+// NewMatcher returns a menu.ScopedMatcher, safe to share between the requests of a server, voting with
+// menu.URLVoter and the voters of the modules of the registry, see menu.Registry.RegisterModule.
+// The requests must carry a matcher scope, see menu.WithMatcherScope, as Handler does.
+func NewMatcher(registry *menu.Registry) menu.Matcher {
+	return menu.NewScopedMatcher(append([]menu.Voter{menu.URLVoter{}}, registry.Voters()...)...)
+}
+
+// NewRenderer returns a renderer.ListRenderer rendering the menus with the filters of the modules of the registry.
+func NewRenderer(matcher menu.Matcher, registry *menu.Registry) renderer.Renderer {
+	return renderer.NewListRenderer(matcher, renderer.WithFilters(registry.Filters()...))
+}
+
+// Hook is a pair of functions run when the application starts and stops, mirroring fx.Hook.
+// A nil function does nothing.
+type Hook struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// NewWarmHook returns a Hook building and caching the given menus on start, all the menus of the provider
+// without names, see menu.CachedProvider.Warm. The menus are dropped from the cache on stop.
+func NewWarmHook(provider *menu.CachedProvider, names ...string) Hook {
+	return Hook{
+		OnStart: func(ctx context.Context) error {
+			return provider.Warm(ctx, names...)
+		},
+		OnStop: func(context.Context) error {
+			provider.InvalidateAll()
+			return nil
+		},
+	}
+}
+
+// Handler is an http.Handler rendering a menu as an HTML fragment, e.g. for menus loaded by the browser.
+// The name of the menu is read from the "name" path value, see http.Request.PathValue, or the "menu" query parameter.
+// The current item is matched against the URL of the "current" query parameter, or the URL of the request.
+type Handler struct {
+	provider menu.Provider
+	renderer renderer.Renderer
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a new Handler rendering the menus of the provider with the renderer.
+//
+// Example usage:
+//
+//	mux.Handle("GET /menus/{name}", menufx.NewHandler(provider, r))
+func NewHandler(provider *menu.CachedProvider, r renderer.Renderer) *Handler {
+	return &Handler{provider: provider, renderer: r}
+}
+
+// ServeHTTP renders the requested menu. It responds with 404 Not Found for an unknown menu
+// and 500 Internal Server Error if the menu cannot be built or rendered.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if name == "" {
+		name = r.URL.Query().Get("menu")
+	}
+
+	current := r.URL
+	if raw := r.URL.Query().Get("current"); raw != "" {
+		u, err := url.Parse(raw)
+		if err != nil {
+			http.Error(w, "invalid current URL", http.StatusBadRequest)
+			return
+		}
+		current = u
+	}
+
+	ctx := menu.WithMatcherScope(menu.WithRequestURL(r.Context(), current))
+
+	root, err := h.provider.Get(ctx, name)
+	if errors.Is(err, menu.ErrMenuNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	content, err := h.renderer.Render(ctx, root)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(content))
+}
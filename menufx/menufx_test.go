@@ -0,0 +1,96 @@
+package menufx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gowool/menu"
+)
+
+func newHandler(t *testing.T) (*menu.CachedProvider, http.Handler) {
+	t.Helper()
+
+	registry := menu.NewRegistry()
+	registry.RegisterBuilder("main", 0, func(_ context.Context, root *menu.Item) error {
+		if _, err := root.AddChild("home", menu.WithLabel("Home"), menu.WithURI("/")); err != nil {
+			return err
+		}
+		_, err := root.AddChild("blog", menu.WithLabel("Blog"), menu.WithURI("/blog"))
+		return err
+	})
+	registry.RegisterBuilder("broken", 0, func(context.Context, *menu.Item) error {
+		return errors.New("source down")
+	})
+
+	provider := NewProvider(registry)
+	handler := NewHandler(provider, NewRenderer(NewMatcher(registry), registry))
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /menus/{name}", handler)
+	mux.Handle("GET /menu", handler)
+	return provider, mux
+}
+
+func TestHandler(t *testing.T) {
+	_, handler := newHandler(t)
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+		body   string
+	}{
+		{name: "path value", target: "/menus/main?current=/blog", status: http.StatusOK, body: `<li class="current last">`},
+		{name: "query parameter", target: "/menu?menu=main", status: http.StatusOK, body: `<a href="/">Home</a>`},
+		{name: "unknown menu", target: "/menus/footer", status: http.StatusNotFound},
+		{name: "failing build", target: "/menus/broken", status: http.StatusInternalServerError},
+		{name: "invalid current URL", target: "/menus/main?current=%25zz%3A", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			if !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body, tt.body)
+			}
+		})
+	}
+}
+
+func TestWarmHook(t *testing.T) {
+	provider, _ := newHandler(t)
+	hook := NewWarmHook(provider, "main")
+
+	if err := hook.OnStart(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !provider.Stats().Menus["main"].Cached {
+		t.Error("OnStart did not cache the menu")
+	}
+
+	if err := hook.OnStop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if provider.Stats().Menus["main"].Cached {
+		t.Error("OnStop did not drop the menu")
+	}
+
+	var warmErr *menu.WarmError
+	if err := NewWarmHook(provider, "broken").OnStart(context.Background()); !errors.As(err, &warmErr) || warmErr.Name != "broken" {
+		t.Errorf("OnStart() error = %v, want a WarmError for broken", err)
+	}
+}
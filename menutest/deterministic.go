@@ -0,0 +1,49 @@
+package menutest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/internal"
+	"github.com/gowool/menu/renderer"
+)
+
+// ErrNondeterministic is returned by CheckDeterministic when two renders of the same menu differ.
+var ErrNondeterministic = errors.New("nondeterministic output")
+
+// CheckDeterministic renders the item n times with the renderer and returns an error wrapping ErrNondeterministic,
+// holding a line diff, if an output differs from the first one. The built-in renderers and templates sort the
+// attributes by name, so golden files and HTTP caches keep working; custom renderers and templates ranging
+// over maps are caught by this check. The random orders of the children must be seeded for the output
+// to be deterministic, see renderer.ContextWithOrderSeed.
+func CheckDeterministic(ctx context.Context, r renderer.Renderer, item *menu.Item, n int, options ...renderer.Option) error {
+	first, err := r.Render(ctx, item, options...)
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i < n; i++ {
+		content, err := r.Render(ctx, item, options...)
+		if err != nil {
+			return err
+		}
+		if content != first {
+			diff := internal.DiffLines(internal.HTMLLines(first), internal.HTMLLines(content))
+			return fmt.Errorf("%w: render %d differs from the first one:\n%s", ErrNondeterministic, i+1, strings.Join(diff, "\n"))
+		}
+	}
+	return nil
+}
+
+// RequireDeterministic fails the test immediately when CheckDeterministic reports different outputs.
+func RequireDeterministic(tb testing.TB, r renderer.Renderer, item *menu.Item, n int, options ...renderer.Option) {
+	tb.Helper()
+
+	if err := CheckDeterministic(context.Background(), r, item, n, options...); err != nil {
+		tb.Fatal(err)
+	}
+}
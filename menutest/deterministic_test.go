@@ -0,0 +1,51 @@
+package menutest
+
+import (
+	"testing"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer"
+)
+
+func deterministicTree(t *testing.T) *menu.Item {
+	t.Helper()
+
+	attributes := map[string]any{"class": "item", "id": "item", "data-a": "a", "data-b": "b", "data-c": "c", "title": "Item"}
+	root, err := menu.NewItem("root", menu.WithChildrenAttributes(map[string]any{"class": "nav", "id": "nav", "data-x": "x", "role": "menubar"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"home", "blog", "about"} {
+		child, err := root.AddChild(name,
+			menu.WithURI("/"+name),
+			menu.WithAttributes(attributes),
+			menu.WithLinkAttributes(map[string]any{"rel": "nofollow", "target": "_blank", "data-track": name, "title": name}),
+			menu.WithLabelAttributes(map[string]any{"class": "label", "data-l": "l", "lang": "en"}),
+			menu.WithChildrenAttributes(map[string]any{"class": "dropdown", "data-d": "d", "role": "menu"}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = child.AddChild(name+"-sub", menu.WithURI("/"+name+"/sub"), menu.WithAttributes(attributes))
+	}
+	return root
+}
+
+func TestRenderersAreDeterministic(t *testing.T) {
+	theme, err := renderer.NewHTMLTheme(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renderers := map[string]renderer.Renderer{
+		"list":       renderer.NewListRenderer(menu.NewCoreMatcher()),
+		"template":   renderer.NewTemplateRenderer(theme, menu.NewCoreMatcher()),
+		"bootstrap5": renderer.NewTemplateRenderer(theme, menu.NewCoreMatcher(), renderer.Bootstrap5Options()...),
+	}
+
+	root := deterministicTree(t)
+	for name, r := range renderers {
+		t.Run(name, func(t *testing.T) {
+			RequireDeterministic(t, r, root, 20)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package menutest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer"
+	"github.com/gowool/menu/yamlmenu"
+)
+
+// ErrFixture is returned by Fixture.Run when the outcome of a fixture differs from its expectations.
+var ErrFixture = errors.New("menu fixture failed")
+
+// Fixture is a declarative test case of the matching and the rendering of a menu: a tree, the URL of the request
+// and the expected outcome. Fixtures are written in YAML, several per file separated by "---", so the regression
+// cases reported by users can be encoded without writing Go:
+//
+//	name: article is current
+//	url: http://localhost/blog/article-1
+//	current: root/blog/article1
+//	tree:
+//	  name: root
+//	  children:
+//	    - name: home
+//	      uri: /
+//	    - name: blog
+//	      uri: /blog
+//	      children:
+//	        - name: article1
+//	          uri: /blog/article-1
+//	classes:
+//	  root/home: [first]
+//	  root/blog: [current-ancestor, last]
+//	  root/blog/article1: [current, first, last]
+//
+// The tree is described like the documents of yamlmenu.Loader. The items are addressed by their key,
+// see menu.Item.Key: their ID if set, their path from the root joined with "/" otherwise.
+type Fixture struct {
+	// Name names the fixture in the test output.
+	Name string `yaml:"name"`
+	// URL is the URL of the request carried by the context of the render.
+	URL string `yaml:"url"`
+	// Tree describes the menu, see yamlmenu.Loader.
+	Tree any `yaml:"tree"`
+	// Current is the key of the expected current item, an empty string expecting none.
+	// The current item is not checked when it is omitted.
+	Current *string `yaml:"current"`
+	// CurrentItems holds the keys of all the expected current items in depth-first order, for the pages linked
+	// from several branches, see menu.CurrentItems. An empty list expects none, a nil list is not checked.
+	CurrentItems []string `yaml:"current_items"`
+	// Classes maps the keys of items to the classes expected on their list element, in any order.
+	// The items missing from the map are not checked, an empty list expects no class.
+	Classes map[string][]string `yaml:"classes"`
+}
+
+// LoadFixtures decodes the fixtures of the files of fsys matching the pattern, see fs.Glob, in the order of
+// the files. Each file holds one or more YAML documents separated by "---".
+func LoadFixtures(fsys fs.FS, pattern string) ([]Fixture, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []Fixture
+	for _, file := range files {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := yaml.NewDecoder(f)
+		for i := 0; ; i++ {
+			var fixture Fixture
+			if err = decoder.Decode(&fixture); err != nil {
+				break
+			}
+			if fixture.Name == "" {
+				fixture.Name = fmt.Sprintf("%s#%d", file, i)
+			}
+			fixtures = append(fixtures, fixture)
+		}
+		_ = f.Close()
+
+		if !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return fixtures, nil
+}
+
+// Run builds the tree of the fixture, matches it against its URL with the matcher and renders it with
+// renderer.ListRenderer and the options. Every difference with the expectations is reported as an error
+// wrapping ErrFixture, joined in the returned error. A nil matcher is a menu.CoreMatcher with a menu.URLVoter.
+func (f Fixture) Run(ctx context.Context, matcher menu.Matcher, options ...renderer.Option) error {
+	if matcher == nil {
+		matcher = menu.NewCoreMatcher(menu.URLVoter{})
+	}
+
+	tree, err := yaml.Marshal(f.Tree)
+	if err != nil {
+		return fmt.Errorf("%w: tree: %w", ErrFixture, err)
+	}
+	root, err := yamlmenu.NewLoader().Load(ctx, tree)
+	if err != nil {
+		return fmt.Errorf("%w: tree: %w", ErrFixture, err)
+	}
+
+	if f.URL != "" {
+		u, err := url.Parse(f.URL)
+		if err != nil {
+			return fmt.Errorf("%w: url: %w", ErrFixture, err)
+		}
+		ctx = menu.WithRequestURL(ctx, u)
+	}
+
+	var errs []error
+
+	if f.Current != nil {
+		var key string
+		if current := menu.CurrentItem(ctx, matcher, root); current != nil {
+			key = current.Key()
+		}
+		if key != *f.Current {
+			errs = append(errs, fmt.Errorf("%w: current item is %q instead of %q", ErrFixture, key, *f.Current))
+		}
+		matcher.Clear()
+	}
+
+	if f.CurrentItems != nil {
+		keys := []string{}
+		for _, current := range menu.CurrentItems(ctx, matcher, root) {
+			keys = append(keys, current.Key())
+		}
+		if !slices.Equal(keys, f.CurrentItems) {
+			errs = append(errs, fmt.Errorf("%w: current items are %q instead of %q", ErrFixture, keys, f.CurrentItems))
+		}
+		matcher.Clear()
+	}
+
+	if f.Classes != nil {
+		options = append([]renderer.Option{renderer.WithCompressed(true)}, options...)
+		options = append(options, renderer.WithMenuKeys(true))
+
+		output, err := renderer.NewListRenderer(matcher, options...).Render(ctx, root)
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("%w: render: %w", ErrFixture, err))...)
+		}
+
+		rendered := renderedClasses(output)
+		for _, key := range slices.Sorted(maps.Keys(f.Classes)) {
+			actual, ok := rendered[key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%w: %s: item not rendered", ErrFixture, key))
+				continue
+			}
+
+			expected := slices.Sorted(slices.Values(f.Classes[key]))
+			if !slices.Equal(actual, expected) {
+				errs = append(errs, fmt.Errorf("%w: %s: classes are %q instead of %q", ErrFixture, key, actual, expected))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RunFixtures runs the fixtures of the files of fsys matching the pattern as subtests named after the fixtures,
+// see LoadFixtures and Fixture.Run, e.g. with the fixtures of the testdata directory:
+//
+//	func TestMenuFixtures(t *testing.T) {
+//		menutest.RunFixtures(t, nil, os.DirFS("testdata"), "*.yaml")
+//	}
+func RunFixtures(t *testing.T, matcher menu.Matcher, fsys fs.FS, pattern string, options ...renderer.Option) {
+	t.Helper()
+
+	fixtures, err := LoadFixtures(fsys, pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			if err := fixture.Run(context.Background(), matcher, options...); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// renderedClasses returns the sorted classes of the list elements of the output by the keys of their items.
+func renderedClasses(output string) map[string][]string {
+	classes := map[string][]string{}
+	for _, tag := range scanTags(output) {
+		if tag.name != "li" {
+			continue
+		}
+
+		var key, class string
+		for _, attr := range tag.attributes {
+			switch attr.name {
+			case "data-menu-key":
+				key = attr.value
+			case "class":
+				class = attr.value
+			}
+		}
+		if key != "" {
+			classes[key] = slices.Sorted(slices.Values(strings.Fields(class)))
+		}
+	}
+	return classes
+}
@@ -0,0 +1,10 @@
+package menutest
+
+import (
+	"os"
+	"testing"
+)
+
+func TestListRendererFixtures(t *testing.T) {
+	RunFixtures(t, nil, os.DirFS("testdata"), "*.yaml")
+}
@@ -0,0 +1,116 @@
+package menutest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gowool/menu"
+)
+
+// ErrInvariant is returned by CheckInvariants when a menu tree is inconsistent.
+var ErrInvariant = errors.New("menu invariant violated")
+
+// InvariantOption enables optional checks of CheckInvariants.
+type InvariantOption func(*invariants)
+
+type invariants struct {
+	ordered bool
+}
+
+// Ordered is an InvariantOption checking that the children of every item are sorted by position,
+// as they are after menu.Item.ReorderChildren.
+func Ordered() InvariantOption {
+	return func(i *invariants) {
+		i.ordered = true
+	}
+}
+
+// CheckInvariants checks the consistency of a menu tree, e.g. after code mutating it:
+//   - no child is nil and the parent of every child is the item holding it
+//   - no item appears twice in the tree, so the tree has no cycle
+//   - the parents of the root do not loop
+//   - the IDs of the items are unique
+//
+// Every violation is reported as an error wrapping ErrInvariant, joined in the returned error.
+func CheckInvariants(root *menu.Item, options ...InvariantOption) error {
+	var opts invariants
+	for _, option := range options {
+		option(&opts)
+	}
+
+	c := checker{
+		opts:    opts,
+		visited: map[*menu.Item]bool{},
+		ids:     map[string]string{},
+	}
+
+	seen := map[*menu.Item]bool{root: true}
+	for parent := root.Parent; parent != nil; parent = parent.Parent {
+		if seen[parent] {
+			c.fail(root.Name, "the parents of the root form a cycle")
+			break
+		}
+		seen[parent] = true
+	}
+
+	c.check(root, root.Name)
+
+	return errors.Join(c.errs...)
+}
+
+// RequireInvariants fails the test immediately when CheckInvariants reports a violation.
+func RequireInvariants(tb testing.TB, root *menu.Item, options ...InvariantOption) {
+	tb.Helper()
+
+	if err := CheckInvariants(root, options...); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+type checker struct {
+	opts    invariants
+	visited map[*menu.Item]bool
+	ids     map[string]string
+	errs    []error
+}
+
+func (c *checker) fail(path, format string, args ...any) {
+	c.errs = append(c.errs, fmt.Errorf("%w: %s: %s", ErrInvariant, path, fmt.Sprintf(format, args...)))
+}
+
+// check walks the tree with the path of names leading to the item, the Path method of the items
+// cannot be trusted on an inconsistent tree.
+func (c *checker) check(item *menu.Item, path string) {
+	if c.visited[item] {
+		c.fail(path, "item already visited, the tree has a cycle or shares a subtree")
+		return
+	}
+	c.visited[item] = true
+
+	if item.ID != "" {
+		if other, ok := c.ids[item.ID]; ok {
+			c.fail(path, "id %q already used by %s", item.ID, other)
+		} else {
+			c.ids[item.ID] = path
+		}
+	}
+
+	for i, child := range item.Children {
+		if child == nil {
+			c.fail(path, "child %d is nil", i)
+			continue
+		}
+
+		childPath := strings.Join([]string{path, child.Name}, "/")
+		if child.Parent != item {
+			c.fail(childPath, "parent is %s instead of %s", child.Parent, item)
+		}
+		if c.opts.ordered && i > 0 && item.Children[i-1] != nil && item.Children[i-1].Position > child.Position {
+			c.fail(childPath, "position %d after position %d", child.Position, item.Children[i-1].Position)
+		}
+
+		c.check(child, childPath)
+	}
+}
@@ -0,0 +1,5 @@
+// Package menutest provides utilities for testing menus and renderers, such as a corpus of hostile input,
+// a checker for the HTML produced from it, checkers of the invariants of menu trees and of the determinism of
+// the rendered output, and a runner of declarative YAML fixtures asserting the outcome of matching and rendering a menu. It is meant to be used from the tests
+// of applications extending the package with their own renderers, themes and templates, or mutating menu trees.
+package menutest
@@ -0,0 +1,234 @@
+package menutest
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"testing"
+
+	"github.com/gowool/menu"
+)
+
+// ErrUnsafeHTML is returned by CheckHTML when the output contains markup injected by hostile input.
+var ErrUnsafeHTML = errors.New("unsafe html")
+
+// HostileLabels are labels trying to inject markup or break out of the element holding them.
+var HostileLabels = []string{
+	`<script>alert(1)</script>`,
+	`<img src=x onerror=alert(1)>`,
+	`<svg/onload=alert(1)>`,
+	`"><script>alert(1)</script>`,
+	`'><iframe src=javascript:alert(1)>`,
+	`</a><script>alert(1)</script>`,
+	`</li></ul><object data=javascript:alert(1)>`,
+	`{{.Item.Label}}`,
+	`&lt;script&gt;alert(1)&lt;/script&gt;`,
+	"<scr\x00ipt>alert(1)</scr\x00ipt>",
+}
+
+// HostileURIs are URIs trying to run code or to break out of the href attribute.
+// The URIs using dangerous schemes are only neutralized with the RejectUnsafeURIs render option.
+var HostileURIs = []string{
+	`javascript:alert(1)`,
+	`JaVaScRiPt:alert(1)`,
+	" javascript:alert(1)",
+	"java\tscript:alert(1)",
+	"\x01javascript:alert(1)",
+	`vbscript:msgbox(1)`,
+	`data:text/html,<script>alert(1)</script>`,
+	`" onmouseover="alert(1)`,
+	`'><script>alert(1)</script>`,
+	`/search?q="><img src=x onerror=alert(1)>`,
+}
+
+// HostileAttributes are attribute values trying to break out of their attribute.
+var HostileAttributes = []string{
+	`" onmouseover="alert(1)`,
+	`' onmouseover='alert(1)`,
+	`"><script>alert(1)</script>`,
+	`x" autofocus onfocus="alert(1)`,
+	`background:url(javascript:alert(1))`,
+	`width: expression(alert(1))`,
+}
+
+// HostileTree returns a menu with an item for every hostile label, URI and attribute value.
+// The attribute values are set as title, class and style of the items and of their links.
+func HostileTree() *menu.Item {
+	root := menu.Must(menu.NewItem("root"))
+
+	for i, label := range HostileLabels {
+		_, _ = root.AddChild(fmt.Sprintf("label%d", i), menu.WithLabel(label), menu.WithURI("/"))
+	}
+	for i, uri := range HostileURIs {
+		_, _ = root.AddChild(fmt.Sprintf("uri%d", i), menu.WithLabel("uri"), menu.WithURI(uri))
+	}
+	for i, value := range HostileAttributes {
+		attributes := map[string]any{"title": value, "class": value, "style": value}
+		_, _ = root.AddChild(fmt.Sprintf("attribute%d", i),
+			menu.WithLabel("attribute"),
+			menu.WithURI("/"),
+			menu.WithAttributes(attributes),
+			menu.WithLinkAttributes(attributes),
+			menu.WithLabelAttributes(attributes),
+		)
+	}
+
+	return root
+}
+
+// FuzzTree returns a menu whose single child, nested in a branch, uses the label, URI and attribute value.
+// It is meant to be called from fuzz targets, with the output of the renderer checked by CheckHTML.
+func FuzzTree(label, uri, attribute string) *menu.Item {
+	attributes := map[string]any{"title": attribute, "class": attribute, "style": attribute}
+
+	root := menu.Must(menu.NewItem("root"))
+	branch, _ := root.AddChild("branch", menu.WithLabel(label), menu.WithAttributes(attributes))
+	_, _ = branch.AddChild("leaf",
+		menu.WithLabel(label),
+		menu.WithURI(uri),
+		menu.WithLinkAttributes(attributes),
+		menu.WithLabelAttributes(attributes),
+	)
+	return root
+}
+
+// AddCorpus seeds the fuzz target with the hostile corpus, as (label, uri, attribute) triples.
+//
+// Example usage:
+//
+//	func FuzzListRenderer(f *testing.F) {
+//		menutest.AddCorpus(f)
+//		f.Fuzz(func(t *testing.T, label, uri, attribute string) {
+//			r := renderer.NewListRenderer(menu.NewCoreMatcher(), renderer.WithRejectUnsafeURIs(true))
+//			out, err := r.Render(context.Background(), menutest.FuzzTree(label, uri, attribute))
+//			if err != nil {
+//				t.Fatal(err)
+//			}
+//			if err = menutest.CheckHTML(out); err != nil {
+//				t.Fatal(err)
+//			}
+//		})
+//	}
+func AddCorpus(f *testing.F) {
+	for _, label := range HostileLabels {
+		f.Add(label, "/", "")
+	}
+	for _, uri := range HostileURIs {
+		f.Add("uri", uri, "")
+	}
+	for _, attribute := range HostileAttributes {
+		f.Add("attribute", "/", attribute)
+	}
+}
+
+// allowedElements are the elements the renderers of the package emit.
+var allowedElements = map[string]bool{
+	"a": true, "span": true, "ul": true, "ol": true, "li": true, "nav": true, "div": true, "section": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "button": true, "select": true,
+	"option": true, "optgroup": true, "p": true, "br": true,
+}
+
+// CheckHTML checks that the output of a renderer given hostile input does not contain injected markup:
+// elements that menus do not emit, such as script, img or iframe, event handler attributes, such as onerror,
+// and links or sources using the javascript:, vbscript: or data: schemes. The problems found are reported
+// as errors wrapping ErrUnsafeHTML.
+func CheckHTML(output string) error {
+	var errs []error
+	for _, tag := range scanTags(output) {
+		if !allowedElements[tag.name] {
+			errs = append(errs, fmt.Errorf("%w: unexpected element <%s>", ErrUnsafeHTML, tag.name))
+		}
+		for _, attr := range tag.attributes {
+			switch {
+			case strings.HasPrefix(attr.name, "on"):
+				errs = append(errs, fmt.Errorf("%w: event handler %s on <%s>", ErrUnsafeHTML, attr.name, tag.name))
+			case attr.name == "href" || attr.name == "src" || attr.name == "action" || attr.name == "formaction":
+				if scheme := menu.URIScheme(attr.value); scheme == "javascript" || scheme == "vbscript" || scheme == "data" {
+					errs = append(errs, fmt.Errorf("%w: %s URI in %s of <%s>", ErrUnsafeHTML, scheme, attr.name, tag.name))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type scannedAttribute struct {
+	name  string
+	value string
+}
+
+type scannedTag struct {
+	name       string
+	attributes []scannedAttribute
+}
+
+// scanTags returns the start tags of the HTML document with their attributes, following the tokenization
+// rules of browsers closely enough to see the markup the way they would.
+func scanTags(s string) []scannedTag {
+	var tags []scannedTag
+	for i := 0; i < len(s); i++ {
+		if s[i] != '<' || i+1 >= len(s) || !isASCIILetter(s[i+1]) {
+			continue
+		}
+
+		i++
+		start := i
+		for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		tag := scannedTag{name: strings.ToLower(s[start:i])}
+
+		for i < len(s) && s[i] != '>' {
+			if isTagSpace(s[i]) || s[i] == '/' {
+				i++
+				continue
+			}
+
+			start = i
+			for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' && s[i] != '/' && s[i] != '=' {
+				i++
+			}
+			attr := scannedAttribute{name: strings.ToLower(s[start:i])}
+
+			for i < len(s) && isTagSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && s[i] == '=' {
+				i++
+				for i < len(s) && isTagSpace(s[i]) {
+					i++
+				}
+				if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+					quote := s[i]
+					i++
+					start = i
+					for i < len(s) && s[i] != quote {
+						i++
+					}
+					attr.value = s[start:i]
+					i++
+				} else {
+					start = i
+					for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' {
+						i++
+					}
+					attr.value = s[start:i]
+				}
+			}
+			attr.value = html.UnescapeString(attr.value)
+			tag.attributes = append(tag.attributes, attr)
+		}
+
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
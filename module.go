@@ -0,0 +1,109 @@
+package menu
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Module bundles a cohesive menu integration shipped by a package, e.g. the permissions of an authorization
+// framework or the menus of a CMS feature, so it is wired in one call with Registry.RegisterModule.
+// Embed BaseModule to implement only the methods the module needs.
+type Module interface {
+	// ProvideVoters returns the voters matching the current item, see Registry.Voters.
+	ProvideVoters() []Voter
+
+	// ProvideFilters returns the filters hiding items at render time, see Registry.Filters.
+	ProvideFilters() []Filter
+
+	// ProvideDecorators returns the stages run on every menu built by the registry, after its extensions,
+	// e.g. DecorateStage(NewBadgeDecorator("New", window, nil)).
+	ProvideDecorators() []Stage
+
+	// ProvideLoaders returns the loaders of the data the module stores menus in, see Registry.Loader.
+	ProvideLoaders() []Loader
+
+	// ExtendMenus registers the builders and the extensions of the module in the registry,
+	// see Registry.RegisterBuilder and Registry.Extend.
+	ExtendMenus(registry *Registry) error
+}
+
+// BaseModule is a Module providing nothing, meant to be embedded by modules implementing only some of the methods.
+type BaseModule struct{}
+
+var _ Module = BaseModule{}
+
+// ProvideVoters returns no voter.
+func (BaseModule) ProvideVoters() []Voter { return nil }
+
+// ProvideFilters returns no filter.
+func (BaseModule) ProvideFilters() []Filter { return nil }
+
+// ProvideDecorators returns no decorator.
+func (BaseModule) ProvideDecorators() []Stage { return nil }
+
+// ProvideLoaders returns no loader.
+func (BaseModule) ProvideLoaders() []Loader { return nil }
+
+// ExtendMenus does nothing.
+func (BaseModule) ExtendMenus(*Registry) error { return nil }
+
+// RegisterModule wires the module in the registry: its builders and extensions are registered with ExtendMenus,
+// its decorators are run on every built menu, and its voters, filters and loaders are collected, in registration
+// order, to be handed to the matcher, the renderers and the loading code, see Voters, Filters and Loader.
+// If ExtendMenus fails, the module is not registered, but the builders and extensions it registered before failing are kept.
+//
+// Example usage:
+//
+//	registry := menu.NewRegistry()
+//	if err := registry.RegisterModule(blog.Module{}); err != nil {
+//		return err
+//	}
+//	matcher := menu.NewCoreMatcher(registry.Voters()...)
+//	r := renderer.NewListRenderer(matcher, renderer.WithFilters(registry.Filters()...))
+func (r *Registry) RegisterModule(module Module) error {
+	if err := module.ExtendMenus(r); err != nil {
+		return fmt.Errorf("register module %T: %w", module, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.modules = append(r.modules, module)
+	r.voters = append(r.voters, module.ProvideVoters()...)
+	r.filters = append(r.filters, module.ProvideFilters()...)
+	r.decorators = append(r.decorators, module.ProvideDecorators()...)
+	r.loaders = append(r.loaders, module.ProvideLoaders()...)
+	return nil
+}
+
+// Modules returns the modules registered with RegisterModule, in registration order.
+func (r *Registry) Modules() []Module {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.modules)
+}
+
+// Voters returns the voters provided by the registered modules.
+func (r *Registry) Voters() []Voter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.voters)
+}
+
+// Filters returns the filters provided by the registered modules.
+func (r *Registry) Filters() []Filter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.filters)
+}
+
+// Loader returns a ChainLoader trying the loaders provided by the registered modules, followed by the given loaders.
+func (r *Registry) Loader(fallback ...Loader) ChainLoader {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return NewChainLoader(slices.Concat(r.loaders, fallback)...)
+}
@@ -0,0 +1,151 @@
+package menu
+
+import (
+	"context"
+	"slices"
+)
+
+// SiblingsOfCurrent returns the parent of the current item, whose children are the current item and its siblings,
+// e.g. to render a "pages in this section" widget:
+//
+//	if section := menu.SiblingsOfCurrent(ctx, matcher, root); section != nil {
+//		html, err = r.Render(ctx, section)
+//	}
+//
+// It returns nil if no item below the root is current.
+func SiblingsOfCurrent(ctx context.Context, matcher Matcher, root *Item) *Item {
+	if current := findCurrent(ctx, matcher, root); current != nil {
+		return current.Parent
+	}
+	return nil
+}
+
+// ChildrenOfCurrent returns the current item, whose children are rendered as secondary navigation.
+// It returns nil if no item below the root is current.
+func ChildrenOfCurrent(ctx context.Context, matcher Matcher, root *Item) *Item {
+	return findCurrent(ctx, matcher, root)
+}
+
+// CurrentItem returns the first item below the root, in depth-first order, that the matcher considers current,
+// e.g. to derive the page title or the canonical URL from the menu:
+//
+//	if current := menu.CurrentItem(ctx, matcher, root); current != nil {
+//		title = current.Label
+//	}
+//
+// It returns nil if no item below the root is current.
+func CurrentItem(ctx context.Context, matcher Matcher, root *Item) *Item {
+	return findCurrent(ctx, matcher, root)
+}
+
+// CurrentItems returns all the items below the root, in depth-first order, that the matcher considers current,
+// e.g. when the same page is linked from several sections of a menu, see CurrentItemsMatcher.
+// It returns nil if no item below the root is current.
+func CurrentItems(ctx context.Context, matcher Matcher, root *Item) []*Item {
+	if m, ok := matcher.(CurrentItemsMatcher); ok {
+		return m.CurrentItems(ctx, root)
+	}
+	return currentItems(ctx, matcher, root)
+}
+
+// currentItems returns the items below the root, in depth-first order, that the matcher considers current.
+func currentItems(ctx context.Context, matcher Matcher, root *Item) []*Item {
+	var items []*Item
+	for item := range root.Descendants() {
+		if matcher.IsCurrent(ctx, item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// findCurrent returns the first item below the root, in depth-first order, that the matcher considers current.
+func findCurrent(ctx context.Context, matcher Matcher, root *Item) *Item {
+	for item := range root.Descendants() {
+		if matcher.IsCurrent(ctx, item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// Neighbors returns the items preceding and following the current item in depth-first order,
+// e.g. for "previous/next page" links of documentation layouts. Only visible items with a URI are considered,
+// see Item.IsVisible: the items hidden or rejected by the filters, e.g. ChannelFilter or RoleFilter,
+// and their children are skipped, as are the children of items not displaying them.
+// It returns nil for a missing neighbor and two nils if no visible item is current.
+func Neighbors(ctx context.Context, matcher Matcher, root *Item, filters ...Filter) (prev, next *Item) {
+	var items []*Item
+	var walk func(item *Item)
+	walk = func(item *Item) {
+		if !item.DisplayChildren {
+			return
+		}
+		for _, child := range item.Children {
+			if !child.IsVisible(ctx, filters...) {
+				continue
+			}
+			if child.URI != "" {
+				items = append(items, child)
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+
+	for i, item := range items {
+		if !matcher.IsCurrent(ctx, item) {
+			continue
+		}
+		if i > 0 {
+			prev = items[i-1]
+		}
+		if i+1 < len(items) {
+			next = items[i+1]
+		}
+		return prev, next
+	}
+	return nil, nil
+}
+
+// Breadcrumbs returns the path from the item, usually the root of a menu, down to the first current item
+// found by the matcher, both included. It returns nil if no item below i is current.
+// When several items are current, see Trails for the paths to all of them.
+//
+// Example usage:
+//
+//	for _, crumb := range root.Breadcrumbs(ctx, matcher) {
+//	    fmt.Println(crumb.Label, crumb.URI)
+//	}
+func (i *Item) Breadcrumbs(ctx context.Context, matcher Matcher) []*Item {
+	current := findCurrent(ctx, matcher, i)
+	if current == nil {
+		return nil
+	}
+	return i.trail(current)
+}
+
+// Trails returns the paths from the item, usually the root of a menu, down to every current item found by
+// the matcher, in depth-first order, e.g. when a page is linked from two branches of a menu.
+// The first trail is the one of Breadcrumbs. It returns nil if no item below i is current.
+func (i *Item) Trails(ctx context.Context, matcher Matcher) [][]*Item {
+	var trails [][]*Item
+	for _, current := range CurrentItems(ctx, matcher, i) {
+		trails = append(trails, i.trail(current))
+	}
+	return trails
+}
+
+// trail returns the path from the item down to its descendant, both included.
+func (i *Item) trail(current *Item) []*Item {
+	trail := []*Item{current}
+	for parent := range current.Ancestors() {
+		trail = append(trail, parent)
+		if parent == i {
+			break
+		}
+	}
+	slices.Reverse(trail)
+
+	return trail
+}
@@ -0,0 +1,94 @@
+package menu
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestTrailsSeveralCurrentItems(t *testing.T) {
+	root, err := NewItem("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blog, _ := root.AddChild("blog", WithURI("/blog"))
+	_, _ = blog.AddChild("post", WithURI("/post"))
+	docs, _ := root.AddChild("docs", WithURI("/docs"))
+	guides, _ := docs.AddChild("guides", WithURI("/docs/guides"))
+	_, _ = guides.AddChild("post", WithURI("/post"))
+	_, _ = root.AddChild("about", WithURI("/about"))
+
+	ctx := WithRequestURL(context.Background(), &url.URL{Path: "/post"})
+	matcher := NewCoreMatcher(URLVoter{})
+
+	trails := root.Trails(ctx, matcher)
+	want := [][]string{
+		{"root", "blog", "post"},
+		{"root", "docs", "guides", "post"},
+	}
+	if len(trails) != len(want) {
+		t.Fatalf("Trails() returned %d trails, want %d", len(trails), len(want))
+	}
+	for i, trail := range trails {
+		var names []string
+		for _, item := range trail {
+			names = append(names, item.Name)
+		}
+		if !slices.Equal(names, want[i]) {
+			t.Errorf("Trails()[%d] = %v, want %v", i, names, want[i])
+		}
+	}
+
+	for _, item := range []*Item{blog, docs, guides} {
+		if !matcher.IsAncestor(ctx, item, nil) {
+			t.Errorf("IsAncestor(%s) = false, want true", item.Name)
+		}
+	}
+	if about := root.Child("about"); matcher.IsAncestor(ctx, about, nil) || matcher.IsCurrent(ctx, about) {
+		t.Errorf("item about is marked as current or ancestor")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	root, err := NewItem("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = root.AddChild("intro", WithURI("/intro"))
+	guide, _ := root.AddChild("guide", WithURI("/guide"))
+	_, _ = guide.AddChild("install", WithURI("/guide/install"))
+	_, _ = guide.AddChild("hidden", WithURI("/guide/hidden"), WithDisplay(false))
+	_, _ = root.AddChild("footer", WithURI("/footer"), WithVisibleIn(ChannelNavbar, false))
+	_, _ = root.AddChild("api", WithURI("/api"))
+
+	tests := []struct {
+		name       string
+		path       string
+		filters    []Filter
+		prev, next string
+	}{
+		{name: "first", path: "/intro", next: "guide"},
+		{name: "middle", path: "/guide/install", prev: "guide", next: "footer"},
+		{name: "last", path: "/api", prev: "footer"},
+		{name: "filtered", path: "/api", filters: []Filter{ChannelFilter(ChannelNavbar)}, prev: "install"},
+		{name: "hidden current", path: "/guide/hidden"},
+		{name: "filtered current", path: "/footer", filters: []Filter{ChannelFilter(ChannelNavbar)}},
+		{name: "no current", path: "/missing"},
+	}
+	name := func(item *Item) string {
+		if item == nil {
+			return ""
+		}
+		return item.Name
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithRequestURL(context.Background(), &url.URL{Path: tt.path})
+			prev, next := Neighbors(ctx, NewCoreMatcher(URLVoter{}), root, tt.filters...)
+			if name(prev) != tt.prev || name(next) != tt.next {
+				t.Errorf("Neighbors() = %q, %q, want %q, %q", name(prev), name(next), tt.prev, tt.next)
+			}
+		})
+	}
+}
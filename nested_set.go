@@ -0,0 +1,114 @@
+package menu
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var _ Loader = NestedSetLoader{}
+
+// ErrMalformedNestedSet is returned by NestedSetLoader when the left/right/depth values do not describe a tree.
+var ErrMalformedNestedSet = errors.New("malformed nested set")
+
+// NestedSetNode is a row of a nested set, the model used by many CMS schemas to store trees:
+// the descendants of a node are the nodes whose Left and Right values lie between its own.
+type NestedSetNode struct {
+	Name    string
+	Label   string
+	URI     string
+	Left    int
+	Right   int
+	Depth   int
+	Options []Option
+}
+
+// NestedSetLoader is a Loader rebuilding the item hierarchy from the rows of a nested set, given as a []NestedSetNode
+// in any order. The rows must describe a single tree: the root contains all the other rows, the ranges of
+// two rows are either nested or disjoint, and Left is lower than Right. Gaps between the values, e.g. left by
+// deleted rows, are accepted. Unless all the depths are zero, the depth of a row must be the depth of its parent plus one.
+// Children are added in Left order.
+type NestedSetLoader struct{}
+
+// NewNestedSetLoader returns a new instance of NestedSetLoader.
+func NewNestedSetLoader() NestedSetLoader {
+	return NestedSetLoader{}
+}
+
+// Load rebuilds the menu described by a []NestedSetNode. Malformed rows are reported with ErrMalformedNestedSet.
+func (l NestedSetLoader) Load(_ context.Context, data any) (*Item, error) {
+	nodes, ok := data.([]NestedSetNode)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected []NestedSetNode, got %T", ErrUnsupported, data)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrMalformedNestedSet)
+	}
+
+	nodes = slices.Clone(nodes)
+	slices.SortStableFunc(nodes, func(a, b NestedSetNode) int {
+		return cmp.Compare(a.Left, b.Left)
+	})
+
+	checkDepth := slices.ContainsFunc(nodes, func(node NestedSetNode) bool {
+		return node.Depth != 0
+	})
+
+	type entry struct {
+		node NestedSetNode
+		item *Item
+	}
+
+	var (
+		root  *Item
+		stack []entry
+	)
+	for _, node := range nodes {
+		if node.Left >= node.Right {
+			return nil, fmt.Errorf("%w: %q has left %d not lower than right %d", ErrMalformedNestedSet, node.Name, node.Left, node.Right)
+		}
+
+		// pop the rows whose range ends before the current row
+		for len(stack) > 0 && stack[len(stack)-1].node.Right < node.Left {
+			stack = stack[:len(stack)-1]
+		}
+
+		item, err := NewItem(node.Name, append([]Option{WithLabel(node.Label), WithURI(node.URI)}, node.Options...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(stack) == 0 {
+			if root != nil {
+				return nil, fmt.Errorf("%w: %q is outside of the root %q", ErrMalformedNestedSet, node.Name, root.Name)
+			}
+			root = item
+			stack = append(stack, entry{node: node, item: item})
+			continue
+		}
+
+		parent := stack[len(stack)-1]
+		if node.Left == parent.node.Left || node.Right >= parent.node.Right {
+			return nil, fmt.Errorf("%w: range of %q [%d, %d] overlaps %q [%d, %d]", ErrMalformedNestedSet,
+				node.Name, node.Left, node.Right, parent.node.Name, parent.node.Left, parent.node.Right)
+		}
+		if checkDepth && node.Depth != parent.node.Depth+1 {
+			return nil, fmt.Errorf("%w: %q has depth %d, expected %d", ErrMalformedNestedSet, node.Name, node.Depth, parent.node.Depth+1)
+		}
+
+		if _, err = parent.item.AddChild(item); err != nil {
+			return nil, err
+		}
+		stack = append(stack, entry{node: node, item: item})
+	}
+
+	return root, nil
+}
+
+// Supports checks if the given data is a []NestedSetNode.
+func (l NestedSetLoader) Supports(data any) bool {
+	_, ok := data.([]NestedSetNode)
+	return ok
+}
@@ -273,9 +273,24 @@ func WithExtra(name string, value any) Option {
 	}
 }
 
-// WithSafeLabel is a function that returns an Option for setting the "safe_label" extra attribute of an Item.
+// WithSafeLabel is a function that returns an Option for setting the "menu.safe_label" extra attribute of an Item.
 func WithSafeLabel(safeLabel bool) Option {
-	return WithExtra("safe_label", safeLabel)
+	return SafeLabelExtra.Option(safeLabel)
+}
+
+// WithRoutes is a function that returns an Option for setting the "menu.routes" extra of an Item.
+func WithRoutes(routes ...string) Option {
+	return RoutesExtra.Option(routes)
+}
+
+// WithTrustedURI is a function that returns an Option for setting the URI of an Item and marking it as trusted,
+// so html/template themes render it as is. Only use it for URIs that do not come from user input.
+func WithTrustedURI(uri string) Option {
+	return func(item *Item) error {
+		item.URI = uri
+		TrustedURIExtra.Set(item, true)
+		return nil
+	}
 }
 
 // WithParent is an option function that sets the parent of an Item.
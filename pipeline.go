@@ -0,0 +1,184 @@
+package menu
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+)
+
+var _ Provider = (*PipelineProvider)(nil)
+
+// Stage post-processes a menu after it has been loaded, e.g. reordering, validating or translating its items.
+type Stage func(ctx context.Context, root *Item) error
+
+// Pipeline is a sequence of stages run in order. It stops at the first failing stage.
+type Pipeline []Stage
+
+// Run runs the stages of the pipeline on the menu.
+func (p Pipeline) Run(ctx context.Context, root *Item) error {
+	for _, stage := range p {
+		if err := stage(ctx, root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReorderStage returns a Stage sorting the children of every item of the menu by Position.
+// Items with the same position keep their relative order.
+func ReorderStage() Stage {
+	return func(_ context.Context, root *Item) error {
+		for item := range root.All() {
+			slices.SortStableFunc(item.Children, func(a, b *Item) int {
+				return cmp.Compare(a.Position, b.Position)
+			})
+		}
+		return nil
+	}
+}
+
+// ValidateStage returns a Stage checking the extras of the menu against their declarations in the registry,
+// see ExtrasRegistry.Validate. A nil registry validates against DefaultExtras.
+func ValidateStage(registry *ExtrasRegistry) Stage {
+	if registry == nil {
+		registry = DefaultExtras
+	}
+	return func(_ context.Context, root *Item) error {
+		return registry.Validate(root)
+	}
+}
+
+// SecurityFilterStage returns a Stage removing from the menu the items, and their descendants,
+// rejected by one of the filters. Unlike render time filters, the items are removed from the tree itself,
+// so it is meant for rules that do not depend on the request, e.g. features disabled by configuration.
+func SecurityFilterStage(filters ...Filter) Stage {
+	return func(ctx context.Context, root *Item) error {
+		var prune func(item *Item)
+		prune = func(item *Item) {
+			for _, child := range slices.Clone(item.Children) {
+				if !acceptedBy(ctx, child, filters) {
+					item.RemoveChild(child)
+					continue
+				}
+				prune(child)
+			}
+		}
+		prune(root)
+		return nil
+	}
+}
+
+func acceptedBy(ctx context.Context, item *Item, filters []Filter) bool {
+	for _, filter := range filters {
+		if !filter(ctx, item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Translator translates a label, e.g. using the locale carried by the context.
+type Translator func(ctx context.Context, label string) string
+
+// TranslateStage returns a Stage replacing the label of every item of the menu by its translation.
+func TranslateStage(translate Translator) Stage {
+	return func(ctx context.Context, root *Item) error {
+		for item := range root.All() {
+			if item.Label != "" {
+				item.Label = translate(ctx, item.Label)
+			}
+		}
+		return nil
+	}
+}
+
+// DecorateStage returns a Stage calling the decorator with every item of the menu in depth-first order,
+// e.g. to add attributes or extras. It stops at the first error.
+func DecorateStage(decorate func(ctx context.Context, item *Item) error) Stage {
+	return func(ctx context.Context, root *Item) error {
+		for item := range root.All() {
+			if err := decorate(ctx, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// PipelineProvider is a Provider running a pipeline on the menus returned by another provider:
+// the global stages registered with Use, then the stages registered for the menu with UseFor.
+//
+// The stages modify the menus, so the underlying provider must return a new tree on every call, as ConfigProvider
+// and Registry do. To cache the processed menus, wrap the PipelineProvider in a CachedProvider.
+type PipelineProvider struct {
+	provider Provider
+	mu       sync.RWMutex
+	global   Pipeline
+	menus    map[string]Pipeline
+}
+
+// NewPipelineProvider creates a new PipelineProvider running the given global stages on the menus of the provider.
+//
+// Example usage:
+//
+//	provider := menu.NewCachedProvider(menu.NewPipelineProvider(source,
+//		menu.ReorderStage(),
+//		menu.ValidateStage(nil),
+//		menu.TranslateStage(translate),
+//	))
+func NewPipelineProvider(provider Provider, stages ...Stage) *PipelineProvider {
+	return &PipelineProvider{
+		provider: provider,
+		global:   slices.Clone(stages),
+		menus:    map[string]Pipeline{},
+	}
+}
+
+// Use appends stages run on every menu.
+func (p *PipelineProvider) Use(stages ...Stage) *PipelineProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.global = append(p.global, stages...)
+	return p
+}
+
+// UseFor appends stages run on the menu with the given name, after the global stages.
+func (p *PipelineProvider) UseFor(name string, stages ...Stage) *PipelineProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.menus[name] = append(p.menus[name], stages...)
+	return p
+}
+
+// Get returns the menu of the underlying provider processed by the pipeline.
+func (p *PipelineProvider) Get(ctx context.Context, name string) (*Item, error) {
+	root, err := p.provider.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	pipeline := slices.Concat(p.global, p.menus[name])
+	p.mu.RUnlock()
+
+	if err = pipeline.Run(ctx, root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Has checks if the underlying provider knows the menu with the given name.
+func (p *PipelineProvider) Has(ctx context.Context, name string) bool {
+	return p.provider.Has(ctx, name)
+}
+
+// Names returns the names of the menus of the underlying provider, if it lists them.
+func (p *PipelineProvider) Names() []string {
+	if lister, ok := p.provider.(interface{ Names() []string }); ok {
+		return lister.Names()
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package menu
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMenuNotFound is returned by a Provider asked for a menu it does not know.
+var ErrMenuNotFound = errors.New("menu not found")
+
+// Provider is an interface that represents a source of named menus, e.g. "main" or "footer".
+type Provider interface {
+	// Get returns the menu with the given name, or an error wrapping ErrMenuNotFound if there is none.
+	Get(ctx context.Context, name string) (*Item, error)
+
+	// Has checks if the provider knows the menu with the given name.
+	Has(ctx context.Context, name string) bool
+}
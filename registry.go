@@ -0,0 +1,233 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var _ Provider = (*Registry)(nil)
+
+// ErrAnchorNotFound is returned when an extension targets a path that does not exist in the built menu.
+var ErrAnchorNotFound = errors.New("menu extension anchor not found")
+
+// BuilderFunc contributes items to a menu. It receives the root item of the menu being built.
+type BuilderFunc func(ctx context.Context, root *Item) error
+
+// ExtendFunc contributes items under an existing item of a menu built by someone else.
+type ExtendFunc func(parent *Item) error
+
+type builder struct {
+	weight int
+	seq    int
+	fn     BuilderFunc
+}
+
+type extension struct {
+	path string
+	fn   ExtendFunc
+}
+
+// Registry is a Provider composing menus from builders contributed by several packages.
+// A menu is built by running its builders in ascending weight order, builders with
+// the same weight run in registration order.
+//
+// Once all builders ran, the extensions registered with Extend are applied in registration order.
+//
+// Items with the same name contributed under the same parent are resolved according to the conflict policy
+// of the registry, see SetConflictPolicy, and reported by Conflicts.
+//
+// The decorators of the modules registered with RegisterModule are run on every built menu, last.
+type Registry struct {
+	mu         sync.RWMutex
+	builders   map[string][]builder
+	extensions map[string][]extension
+	policy     ConflictPolicy
+	conflicts  map[string][]Conflict
+	seq        int
+	modules    []Module
+	voters     []Voter
+	filters    []Filter
+	decorators Pipeline
+	loaders    []Loader
+}
+
+// NewRegistry creates an empty Registry using the ConflictKeep policy.
+func NewRegistry() *Registry {
+	return &Registry{
+		builders:   map[string][]builder{},
+		extensions: map[string][]extension{},
+		conflicts:  map[string][]Conflict{},
+	}
+}
+
+// SetConflictPolicy sets how items with the same name contributed under the same parent are resolved.
+func (r *Registry) SetConflictPolicy(policy ConflictPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.policy = policy
+}
+
+// Conflicts returns the conflicts found by the last build of the menu with the given name, for debugging.
+func (r *Registry) Conflicts(name string) []Conflict {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.conflicts[name])
+}
+
+// RegisterBuilder registers a builder contributing to the menu with the given name.
+func (r *Registry) RegisterBuilder(name string, weight int, fn BuilderFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	r.builders[name] = append(r.builders[name], builder{weight: weight, seq: r.seq, fn: fn})
+}
+
+// Extend registers an extension appending items under the item at the given path of the menu with the given name.
+// The path is made of the item names below the root separated by "/", e.g. "settings/advanced";
+// an empty path targets the root. Extensions are resolved after all builders of the menu ran,
+// Get returns an error wrapping ErrAnchorNotFound if the path does not exist.
+func (r *Registry) Extend(name, path string, fn ExtendFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.extensions[name] = append(r.extensions[name], extension{path: path, fn: fn})
+}
+
+// Get builds the menu with the given name. Every call builds a new tree.
+func (r *Registry) Get(ctx context.Context, name string) (*Item, error) {
+	builders := r.sorted(name)
+	if len(builders) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMenuNotFound, name)
+	}
+
+	root, err := NewItem(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	extensions := slices.Clone(r.extensions[name])
+	decorators := slices.Clone(r.decorators)
+	resolver := &conflictResolver{menu: name, policy: r.policy, weights: map[*Item]int{root: 0}}
+	r.mu.RUnlock()
+
+	for _, b := range builders {
+		if err = b.fn(ctx, root); err != nil {
+			return nil, fmt.Errorf("build menu %s: %w", name, err)
+		}
+		resolver.attribute(root, b.weight)
+	}
+
+	for _, e := range extensions {
+		parent := root
+		if e.path != "" {
+			parent = root.ItemAt(append([]string{root.Name}, strings.Split(e.path, "/")...))
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("extend menu %s: %w: %s", name, ErrAnchorNotFound, e.path)
+		}
+		if err = e.fn(parent); err != nil {
+			return nil, fmt.Errorf("extend menu %s at %q: %w", name, e.path, err)
+		}
+		resolver.attribute(root, 0)
+	}
+
+	err = resolver.resolve(root)
+
+	r.mu.Lock()
+	r.conflicts[name] = resolver.conflicts
+	r.mu.Unlock()
+
+	if err != nil {
+		return nil, fmt.Errorf("build menu %s: %w", name, err)
+	}
+
+	if err = decorators.Run(ctx, root); err != nil {
+		return nil, fmt.Errorf("decorate menu %s: %w", name, err)
+	}
+
+	return root, nil
+}
+
+// Has checks if at least one builder contributes to the menu with the given name.
+func (r *Registry) Has(_ context.Context, name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.builders[name]) > 0
+}
+
+// Names returns the sorted names of the menus having builders.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.builders))
+	for name := range r.builders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (r *Registry) sorted(name string) []builder {
+	r.mu.RLock()
+	builders := slices.Clone(r.builders[name])
+	r.mu.RUnlock()
+
+	slices.SortFunc(builders, func(a, b builder) int {
+		if a.weight != b.weight {
+			return a.weight - b.weight
+		}
+		return a.seq - b.seq
+	})
+	return builders
+}
+
+var defaultRegistry atomic.Pointer[Registry]
+
+func init() {
+	defaultRegistry.Store(NewRegistry())
+}
+
+// DefaultRegistry returns the global registry used by RegisterBuilder.
+func DefaultRegistry() *Registry {
+	return defaultRegistry.Load()
+}
+
+// SetDefaultRegistry replaces the global registry, e.g. to isolate tests.
+// Builders registered in the previous registry are not carried over.
+func SetDefaultRegistry(registry *Registry) {
+	defaultRegistry.Store(registry)
+}
+
+// RegisterBuilder registers a builder in the default registry. Feature packages call it from init functions
+// to contribute items to menus owned by the application:
+//
+//	func init() {
+//		menu.RegisterBuilder("main", 10, func(ctx context.Context, root *menu.Item) error {
+//			_, err := root.AddChild("blog", menu.WithLabel("Blog"), menu.WithURI("/blog"))
+//			return err
+//		})
+//	}
+func RegisterBuilder(name string, weight int, fn BuilderFunc) {
+	DefaultRegistry().RegisterBuilder(name, weight, fn)
+}
+
+// Extend registers an extension of a menu in the default registry, see Registry.Extend.
+func Extend(name, path string, fn ExtendFunc) {
+	DefaultRegistry().Extend(name, path, fn)
+}
+
+// RegisterModule registers a module in the default registry, see Registry.RegisterModule.
+func RegisterModule(module Module) error {
+	return DefaultRegistry().RegisterModule(module)
+}
@@ -0,0 +1,78 @@
+package renderer
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/gowool/menu"
+)
+
+// AMPTemplate is the template of HTMLTheme rendering a menu valid in AMP pages: a button toggling an <amp-sidebar>
+// holding an <amp-nested-menu>, whose branches open their children as nested submenus with a back header.
+// A branch with a URI is linked as the first item of its submenu. The output holds no script nor event handler
+// attribute, the toggle button and the submenus rely on AMP actions and components only.
+const AMPTemplate = "@amp/sidebar.html"
+
+// AMPPreset is the name of the preset registered with AMPOptions.
+const AMPPreset = "amp"
+
+// AMP option extras, read by the templates of AMPTemplate.
+var (
+	// AMPSidebarIDExtra holds the id of the <amp-sidebar> element, it must be unique in the page.
+	AMPSidebarIDExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "amp_sidebar_id"), "menu-sidebar", "amp_sidebar_id")
+
+	// AMPSideExtra holds the side the sidebar opens from, "left" or "right".
+	AMPSideExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "amp_side"), "left", "amp_side")
+
+	// AMPToggleLabelExtra holds the label of the button toggling the sidebar, no button is rendered when empty,
+	// e.g. when the page renders its own button with the on="tap:menu-sidebar.toggle" action.
+	AMPToggleLabelExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "amp_toggle_label"), "Menu", "amp_toggle_label")
+
+	// AMPBackLabelExtra holds the label of the header closing a submenu.
+	AMPBackLabelExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "amp_back_label"), "Back", "amp_back_label")
+)
+
+func init() {
+	RegisterPreset(AMPPreset, AMPOptions()...)
+}
+
+// AMPOptions returns the options rendering an AMP sidebar menu with TemplateRenderer: the AMPTemplate template,
+// the URIs able to run code rejected and the labels always escaped, since AMP pages cannot hold custom scripts.
+// They are registered as the AMPPreset preset.
+//
+// Example usage:
+//
+//	r := renderer.NewTemplateRenderer(theme, matcher, renderer.AMPOptions()...)
+//	html, err := r.Render(ctx, root, renderer.AMPSideExtra.Option("right"))
+func AMPOptions() []Option {
+	return []Option{
+		WithTemplate(AMPTemplate),
+		WithCurrentClass("active"),
+		WithAncestorClass("active-ancestor"),
+		WithFirstClass(""),
+		WithLastClass(""),
+		WithDropdownMode(DropdownNone),
+		WithRejectUnsafeURIs(true),
+		WithAllowSafeLabels(false),
+		AMPSidebarIDExtra.Option(AMPSidebarIDExtra.def),
+		AMPSideExtra.Option(AMPSideExtra.def),
+		AMPToggleLabelExtra.Option(AMPToggleLabelExtra.def),
+		AMPBackLabelExtra.Option(AMPBackLabelExtra.def),
+	}
+}
+
+// AMPAttributes returns a copy of the attributes without the event handler attributes, such as onclick,
+// which are invalid in AMP pages. The "on" attribute of the AMP actions is kept.
+func (o *Options) AMPAttributes(attributes map[string]any) map[string]any {
+	attributes = maps.Clone(attributes)
+	if attributes == nil {
+		return map[string]any{}
+	}
+
+	for name := range attributes {
+		if lower := strings.ToLower(name); len(lower) > 2 && strings.HasPrefix(lower, "on") {
+			delete(attributes, name)
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,52 @@
+package renderer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gowool/menu"
+)
+
+// AncestorDistance returns the distance from the item to the closest current item among its descendants,
+// 1 for the parent of a current item, 2 for its grandparent, and so on, or 0 if the item is not an ancestor
+// of a current item up to the MatchingDepth option.
+func (o *Options) AncestorDistance(ctx context.Context, matcher menu.Matcher, item *menu.Item) int {
+	if matcher == nil || !matcher.IsAncestor(ctx, item, o.MatchingDepth) {
+		return 0
+	}
+	for distance := 1; ; distance++ {
+		if matcher.IsAncestor(ctx, item, &distance) {
+			return distance
+		}
+	}
+}
+
+// AncestorDepthClass returns the class of an ancestor of a current item made of the AncestorDepthPrefix option
+// followed by its distance from the current item, see AncestorDistance, e.g. "current-ancestor-1" for the parent
+// and "current-ancestor-2" for the grandparent, so designs can style the trail with decreasing emphasis.
+// It returns an empty string when the option is not set or the item is not an ancestor of a current item.
+func (o *Options) AncestorDepthClass(ctx context.Context, matcher menu.Matcher, item *menu.Item) string {
+	if o.AncestorDepthPrefix == "" {
+		return ""
+	}
+	if distance := o.AncestorDistance(ctx, matcher, item); distance > 0 {
+		return o.AncestorDepthPrefix + strconv.Itoa(distance)
+	}
+	return ""
+}
+
+// SetAncestorDepthPrefix sets the value of the AncestorDepthPrefix field in the Options struct and returns the pointer
+// to the Options struct.
+func (o *Options) SetAncestorDepthPrefix(prefix string) *Options {
+	o.AncestorDepthPrefix = prefix
+	return o
+}
+
+// WithAncestorDepthPrefix is a function that returns an Option for adding the distance from the current item
+// to the class of its ancestors, e.g. WithAncestorDepthPrefix("current-ancestor-"), see Options.AncestorDepthClass.
+// The ancestors keep the AncestorClass.
+func WithAncestorDepthPrefix(prefix string) Option {
+	return func(options *Options) {
+		options.SetAncestorDepthPrefix(prefix)
+	}
+}
@@ -0,0 +1,71 @@
+package renderer
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/internal/benchtree"
+)
+
+// benchMatchers are the matchers the benchmarks run with: CoreMatcher caching the current items during
+// a render, and ScopedMatcher without a scope in the context, which consults the voters on every call.
+var benchMatchers = []struct {
+	name    string
+	matcher func() menu.Matcher
+}{
+	{name: "cached", matcher: func() menu.Matcher { return menu.NewCoreMatcher(menu.URLVoter{}) }},
+	{name: "uncached", matcher: func() menu.Matcher { return menu.NewScopedMatcher(menu.URLVoter{}) }},
+}
+
+func benchRender(b *testing.B, newRenderer func(matcher menu.Matcher) Renderer) {
+	u, _ := url.Parse("http://localhost" + benchtree.CurrentURI)
+	ctx := menu.WithRequestURL(context.Background(), u)
+
+	for _, shape := range benchtree.Shapes() {
+		item := shape.Build()
+
+		for _, m := range benchMatchers {
+			r := newRenderer(m.matcher())
+
+			b.Run(shape.Name+"/"+m.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := r.Render(ctx, item); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+
+			b.Run(shape.Name+"/"+m.name+"/parallel", func(b *testing.B) {
+				b.ReportAllocs()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						if _, err := r.Render(ctx, item); err != nil {
+							b.Error(err)
+							return
+						}
+					}
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkListRenderer(b *testing.B) {
+	benchRender(b, func(matcher menu.Matcher) Renderer {
+		return NewListRenderer(matcher)
+	})
+}
+
+func BenchmarkTemplateRenderer(b *testing.B) {
+	theme, err := NewHTMLTheme(nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	benchRender(b, func(matcher menu.Matcher) Renderer {
+		return NewTemplateRenderer(theme, matcher)
+	})
+}
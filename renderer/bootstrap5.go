@@ -0,0 +1,58 @@
+package renderer
+
+import "github.com/gowool/menu"
+
+// Bootstrap5Template is the template of HTMLTheme rendering a Bootstrap 5 navbar: the brand, the toggler
+// collapsing the menu on small screens, the top level items as nav links and their children as dropdown menus.
+// Dropdown children without URI are rendered as dropdown headers, or as dividers without label.
+const Bootstrap5Template = "@bootstrap5/navbar.html"
+
+// Bootstrap5Preset is the name of the preset registered with Bootstrap5Options.
+const Bootstrap5Preset = "bootstrap5"
+
+// Bootstrap 5 option extras, read by the templates of Bootstrap5Template.
+var (
+	// Bootstrap5NavbarClassExtra holds the class of the <nav> element.
+	Bootstrap5NavbarClassExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "bootstrap5_navbar_class"), "navbar navbar-expand-lg bg-body-tertiary", "bootstrap5_navbar_class")
+
+	// Bootstrap5ContainerClassExtra holds the class of the container of the navbar.
+	Bootstrap5ContainerClassExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "bootstrap5_container_class"), "container-fluid", "bootstrap5_container_class")
+
+	// Bootstrap5CollapseIDExtra holds the id of the collapsed part of the navbar, it must be unique in the page.
+	Bootstrap5CollapseIDExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "bootstrap5_collapse_id"), "navbar-menu", "bootstrap5_collapse_id")
+)
+
+func init() {
+	RegisterPreset(Bootstrap5Preset, Bootstrap5Options()...)
+}
+
+// Bootstrap5Options returns the options rendering a ready-to-use Bootstrap 5 navbar with TemplateRenderer:
+// the Bootstrap5Template template, two levels, the active class and the dropdown toggles of Bootstrap.
+// They are registered as the Bootstrap5Preset preset.
+//
+// Example usage:
+//
+//	r := renderer.NewTemplateRenderer(theme, matcher, renderer.Bootstrap5Options()...)
+//	html, err := r.Render(ctx, root, renderer.WithBrand(&renderer.Brand{Label: "Acme", URI: "/"}))
+func Bootstrap5Options() []Option {
+	depth := 2
+	return []Option{
+		WithTemplate(Bootstrap5Template),
+		WithDepth(&depth),
+		WithCurrentClass("active"),
+		WithAncestorClass("active"),
+		WithFirstClass(""),
+		WithLastClass(""),
+		WithDropdownMode(DropdownClick),
+		WithHoverClass("dropdown-hover"),
+		WithToggleClass("dropdown-toggle"),
+		WithToggleAttributes(map[string]any{
+			"data-bs-toggle": "dropdown",
+			"role":           "button",
+			"aria-expanded":  "false",
+		}),
+		Bootstrap5NavbarClassExtra.Option(Bootstrap5NavbarClassExtra.def),
+		Bootstrap5ContainerClassExtra.Option(Bootstrap5ContainerClassExtra.def),
+		Bootstrap5CollapseIDExtra.Option(Bootstrap5CollapseIDExtra.def),
+	}
+}
@@ -0,0 +1,74 @@
+package renderer
+
+import (
+	"context"
+
+	"github.com/gowool/menu"
+)
+
+// Expands reports whether the children of the item are rendered according to the CurrentBranchDepth option.
+// It always returns true when the option is not set, and for the "more" branch of the MaxItems option.
+func (o *Options) Expands(item *menu.Item) bool {
+	return o.expanded == nil || o.expanded[item] || (o.more != nil && item == o.more)
+}
+
+// prepare computes the render state derived from the tree and the matcher, such as the items expanded
+// by the CurrentBranchDepth option and the items granted by the Budget option, and resets the orders
+// of the children of the Order option. It returns the item to render, the root itself unless the StartLevel
+// or RootFromCurrent options select another one.
+// Renderers call it once per Render on their copy of the options.
+func (o *Options) prepare(ctx context.Context, matcher menu.Matcher, root *menu.Item) *menu.Item {
+	root = o.startItem(ctx, matcher, root)
+	o.root = root
+	o.prepareOrder()
+	o.prepareMore(ctx, root)
+	o.prepareBranch(ctx, matcher, root)
+	o.prepareBudget(ctx, root)
+	return root
+}
+
+// prepareBranch computes the items expanded by the CurrentBranchDepth option.
+func (o *Options) prepareBranch(ctx context.Context, matcher menu.Matcher, root *menu.Item) {
+	o.expanded = nil
+	if o.CurrentBranchDepth == nil {
+		return
+	}
+
+	o.expanded = map[*menu.Item]bool{}
+
+	var found bool
+	var walk func(item *menu.Item)
+	walk = func(item *menu.Item) {
+		for _, child := range o.Children(ctx, item) {
+			if matcher.IsCurrent(ctx, child) {
+				found = true
+				o.expandBranch(child)
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+
+	if !found {
+		o.expandBranch(root)
+	}
+}
+
+// expandBranch expands the ancestors of the current item and its descendants up to CurrentBranchDepth levels.
+func (o *Options) expandBranch(current *menu.Item) {
+	for parent := current.Parent; parent != nil; parent = parent.Parent {
+		o.expanded[parent] = true
+	}
+
+	var expand func(item *menu.Item, depth int)
+	expand = func(item *menu.Item, depth int) {
+		if depth >= *o.CurrentBranchDepth {
+			return
+		}
+		o.expanded[item] = true
+		for _, child := range item.Children {
+			expand(child, depth+1)
+		}
+	}
+	expand(current, 0)
+}
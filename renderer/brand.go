@@ -0,0 +1,92 @@
+package renderer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gowool/menu/renderer/htmlutil"
+)
+
+// DefaultBrandClass is the class of the brand link when Brand.Class is empty.
+const DefaultBrandClass = "navbar-brand"
+
+// Brand describes the brand rendered at the start of a navbar: a home link holding a logo and/or a label.
+// It is configured with the Brand option and rendered before the list of the menu by ListRenderer
+// and by the "@menu/brand.html" template of the built-in theme.
+type Brand struct {
+	// Label is the text of the link, e.g. the name of the site.
+	Label string `json:"label,omitempty"`
+	// URI is the target of the link, "/" when empty.
+	URI string `json:"uri,omitempty"`
+	// Class is the class of the link, DefaultBrandClass when empty.
+	Class string `json:"class,omitempty"`
+	// Logo is the source of the logo image, no image is rendered when empty.
+	Logo string `json:"logo,omitempty"`
+	// LogoSrcset lists the responsive variants of the logo, e.g. "logo.png 1x, logo@2x.png 2x".
+	LogoSrcset string `json:"logo_srcset,omitempty"`
+	// LogoAlt is the alternative text of the logo, the label is used when empty.
+	LogoAlt string `json:"logo_alt,omitempty"`
+	// LogoWidth and LogoHeight are the dimensions of the logo, omitted when zero.
+	LogoWidth  int `json:"logo_width,omitempty"`
+	LogoHeight int `json:"logo_height,omitempty"`
+}
+
+// Href returns the target of the link.
+func (b *Brand) Href() string {
+	if b.URI == "" {
+		return "/"
+	}
+	return b.URI
+}
+
+// LinkClass returns the class of the link.
+func (b *Brand) LinkClass() string {
+	if b.Class == "" {
+		return DefaultBrandClass
+	}
+	return b.Class
+}
+
+// Alt returns the alternative text of the logo.
+func (b *Brand) Alt() string {
+	if b.LogoAlt == "" {
+		return b.Label
+	}
+	return b.LogoAlt
+}
+
+// LogoAttributes returns the attributes of the logo image.
+func (b *Brand) LogoAttributes() map[string]any {
+	attributes := map[string]any{"src": b.Logo, "alt": b.Alt()}
+	if b.LogoSrcset != "" {
+		attributes["srcset"] = b.LogoSrcset
+	}
+	if b.LogoWidth > 0 {
+		attributes["width"] = strconv.Itoa(b.LogoWidth)
+	}
+	if b.LogoHeight > 0 {
+		attributes["height"] = strconv.Itoa(b.LogoHeight)
+	}
+	return attributes
+}
+
+// html renders the brand link, with the URI checked like the URIs of the items.
+// The brand is rendered as a span if its URI is rejected.
+func (b *Brand) html(options *Options) string {
+	tag := "span"
+
+	var s strings.Builder
+	if uri := options.URI(b.Href()); uri != "" {
+		tag = "a"
+		s.WriteString(fmt.Sprintf(`<a class="%s" href="%s">`, htmlutil.EncodeHTML(b.LinkClass()), htmlutil.EncodeAttribute("href", uri)))
+	} else {
+		s.WriteString(fmt.Sprintf(`<span class="%s">`, htmlutil.EncodeHTML(b.LinkClass())))
+	}
+	if b.Logo != "" {
+		s.WriteString(fmt.Sprintf("<img%s>", htmlutil.Attributes(b.LogoAttributes())))
+	}
+	s.WriteString(htmlutil.EncodeHTML(b.Label))
+	s.WriteString("</" + tag + ">")
+	return s.String()
+}
@@ -0,0 +1,157 @@
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer/htmlutil"
+)
+
+var _ Renderer = BreadcrumbRenderer{}
+
+// Breadcrumb option extras.
+var (
+	// BreadcrumbClassExtra holds the class of the <ol> element.
+	BreadcrumbClassExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "breadcrumb_class"), "breadcrumb", "breadcrumb_class")
+
+	// BreadcrumbItemClassExtra holds the class of the <li> elements.
+	BreadcrumbItemClassExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "breadcrumb_item_class"), "breadcrumb-item", "breadcrumb_item_class")
+
+	// BreadcrumbSeparatorExtra holds a separator rendered before every item but the first one.
+	// It is empty by default, leaving separators to CSS.
+	BreadcrumbSeparatorExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "breadcrumb_separator"), "", "breadcrumb_separator")
+
+	// BreadcrumbLastAsLinkExtra renders the last item as a link instead of text.
+	BreadcrumbLastAsLinkExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "breadcrumb_last_as_link"), false, "breadcrumb_last_as_link")
+
+	// BreadcrumbHomeLabelExtra holds the label of a home item prepended to the trail. No home item is added when empty.
+	BreadcrumbHomeLabelExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "breadcrumb_home_label"), "", "breadcrumb_home_label")
+
+	// BreadcrumbHomeURIExtra holds the URI of the home item.
+	BreadcrumbHomeURIExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "breadcrumb_home_uri"), "/", "breadcrumb_home_uri")
+)
+
+// BreadcrumbRenderer renders the active trail, from the top level item down to the current item found by the matcher,
+// the first one in depth-first order when several are, see menu.Item.Breadcrumbs, as an ordered list:
+//
+//	<ol class="breadcrumb">
+//	  <li class="breadcrumb-item"><a href="/">Home</a></li>
+//	  <li class="breadcrumb-item"><a href="/blog">Blog</a></li>
+//	  <li class="breadcrumb-item current" aria-current="page">Article</li>
+//	</ol>
+//
+// The root item itself is not part of the trail; use BreadcrumbHomeLabelExtra to prepend a home item.
+// Items are skipped when they are hidden in the Channel or rejected by the Filters option, but not when
+// their Display field is false: pages hidden from menus still belong to the trail.
+// Nothing is rendered if no item is current.
+type BreadcrumbRenderer struct {
+	matcher menu.Matcher
+	options *Options
+}
+
+// NewBreadcrumbRenderer creates a new BreadcrumbRenderer with the given matcher and options.
+// The Channel option defaults to menu.ChannelBreadcrumbs.
+func NewBreadcrumbRenderer(matcher menu.Matcher, options ...Option) BreadcrumbRenderer {
+	return BreadcrumbRenderer{
+		matcher: matcher,
+		options: NewOptions(append([]Option{WithChannel(menu.ChannelBreadcrumbs)}, options...)...),
+	}
+}
+
+// Render renders the breadcrumbs of the menu.
+func (r BreadcrumbRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	ctx = menu.WithMatcher(ctx, r.matcher)
+	opts := r.options.Copy().Apply(options...)
+
+	content, err := run(ctx, "breadcrumb", item, opts, func(ctx context.Context) (string, error) {
+		return r.render(ctx, item, opts), nil
+	})
+
+	if opts.ClearMatcher {
+		r.matcher.Clear()
+	}
+
+	return content, err
+}
+
+type crumb struct {
+	label string
+	uri   string
+	item  *menu.Item
+}
+
+func (r BreadcrumbRenderer) render(ctx context.Context, root *menu.Item, options *Options) string {
+	trail := root.Breadcrumbs(ctx, r.matcher)
+	if len(trail) < 2 {
+		return ""
+	}
+
+	crumbs := make([]crumb, 0, len(trail))
+	if home := BreadcrumbHomeLabelExtra.Get(options); home != "" {
+		uri := BreadcrumbHomeURIExtra.Get(options)
+		if trail[1].URI != uri {
+			crumbs = append(crumbs, crumb{label: html.EscapeString(home), uri: uri})
+		}
+	}
+	for _, item := range trail[1:] {
+		// hidden pages are part of the trail, only the channel and the filters hide breadcrumbs
+		if !r.visible(ctx, item, options) {
+			continue
+		}
+		crumbs = append(crumbs, crumb{label: label(ctx, item, options), uri: item.URI, item: item})
+	}
+	if len(crumbs) == 0 {
+		return ""
+	}
+
+	separator := BreadcrumbSeparatorExtra.Get(options)
+	itemClass := BreadcrumbItemClassExtra.Get(options)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`<ol class="%s">`, html.EscapeString(BreadcrumbClassExtra.Get(options))))
+	for i, c := range crumbs {
+		last := i == len(crumbs)-1
+
+		classes := []string{itemClass}
+		attributes := map[string]any{}
+		if c.item != nil {
+			classes = append(classes, menu.ClassList(c.item.Attributes["class"])...)
+		}
+		if last {
+			classes = append(classes, options.CurrentClass)
+			attributes["aria-current"] = "page"
+		}
+		attributes["class"] = htmlutil.Classes(classes)
+
+		b.WriteString(fmt.Sprintf("<li%s>", htmlutil.Attributes(attributes)))
+		if i > 0 && separator != "" {
+			b.WriteString(fmt.Sprintf(`<span class="breadcrumb-separator" aria-hidden="true">%s</span>`, html.EscapeString(separator)))
+		}
+		if uri := options.URI(c.uri); uri != "" && (!last || BreadcrumbLastAsLinkExtra.Get(options)) {
+			b.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, htmlutil.EncodeAttribute("href", uri), c.label))
+		} else {
+			b.WriteString(c.label)
+		}
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ol>")
+
+	return b.String()
+}
+
+// visible checks if the item is visible in the Channel and accepted by the Filters option.
+// Unlike Options.IsVisible, the Display field is ignored.
+func (r BreadcrumbRenderer) visible(ctx context.Context, item *menu.Item, options *Options) bool {
+	if !item.IsVisibleIn(options.Channel) {
+		return false
+	}
+	for _, filter := range options.Filters {
+		if !filter(ctx, item) {
+			return false
+		}
+	}
+	return true
+}
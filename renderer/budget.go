@@ -0,0 +1,126 @@
+package renderer
+
+import (
+	"context"
+
+	"github.com/gowool/menu"
+)
+
+// DefaultBudgetMarker is the truncation marker rendered when a Budget without Marker is exceeded.
+const DefaultBudgetMarker = "<!-- menu truncated -->"
+
+// Budget caps the size of a rendered menu, protecting the latency of the pages when a misconfigured loader
+// produces a pathological tree. The items are granted level by level, so a truncated menu keeps its top levels.
+// When the budget is exceeded, the remaining items are not rendered, the Marker is rendered after the menu
+// and the truncation is reported to the BudgetReport of the context, see ContextWithBudgetReport.
+//
+// The Budget option is applied by ListRenderer, TemplateRenderer and JSONRenderer.
+type Budget struct {
+	// MaxItems is the maximum number of rendered items, 0 means no limit.
+	MaxItems int `json:"max_items,omitempty"`
+	// MaxDepth is the maximum number of rendered levels below the root, 0 means no limit.
+	MaxDepth int `json:"max_depth,omitempty"`
+	// Marker is the raw HTML rendered after a truncated menu, DefaultBudgetMarker when empty.
+	Marker string `json:"marker,omitempty"`
+}
+
+// BudgetReport reports the effect of the Budget option on a render, see ContextWithBudgetReport.
+type BudgetReport struct {
+	// Truncated reports whether the budget was exceeded.
+	Truncated bool
+	// Rendered is the number of items granted by the budget.
+	Rendered int
+	// Skipped is the number of visible items left out by the budget.
+	Skipped int
+}
+
+type budgetReportKey struct{}
+
+// ContextWithBudgetReport returns a copy of the context and a report filled by the renders using it,
+// so the caller can tell whether the menu was truncated by the Budget option:
+//
+//	ctx, report := renderer.ContextWithBudgetReport(ctx)
+//	html, err := r.Render(ctx, root, renderer.WithBudget(&renderer.Budget{MaxItems: 500}))
+//	if report.Truncated {
+//		logger.Warn("menu truncated", "skipped", report.Skipped)
+//	}
+func ContextWithBudgetReport(ctx context.Context) (context.Context, *BudgetReport) {
+	report := &BudgetReport{}
+	return context.WithValue(ctx, budgetReportKey{}, report), report
+}
+
+// Truncated reports whether the items of the menu were truncated by the Budget option during the render.
+func (o *Options) Truncated() bool {
+	return o.truncated
+}
+
+// BudgetMarker returns the truncation marker of the Budget option if the menu was truncated, or an empty string.
+func (o *Options) BudgetMarker() string {
+	if !o.truncated {
+		return ""
+	}
+	if o.Budget.Marker == "" {
+		return DefaultBudgetMarker
+	}
+	return o.Budget.Marker
+}
+
+// SetBudget sets the value of the Budget field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetBudget(budget *Budget) *Options {
+	o.Budget = budget
+	return o
+}
+
+// WithBudget is a function that returns an Option capping the number of rendered items and levels,
+// e.g. WithBudget(&Budget{MaxItems: 500, MaxDepth: 4}). A nil budget renders the whole menu.
+func WithBudget(budget *Budget) Option {
+	return func(options *Options) {
+		options.SetBudget(budget)
+	}
+}
+
+// prepareBudget grants the Budget option to the items that would be rendered, breadth-first, and hides the others.
+func (o *Options) prepareBudget(ctx context.Context, root *menu.Item) {
+	o.granted, o.truncated = nil, false
+	if o.Budget == nil || (o.Budget.MaxItems <= 0 && o.Budget.MaxDepth <= 0) {
+		return
+	}
+
+	granted := map[*menu.Item]bool{}
+	var skipped int
+
+	type entry struct {
+		item  *menu.Item
+		level int
+	}
+	queue := []entry{{item: root}}
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+
+		if (o.Depth != nil && e.level >= *o.Depth) || !o.Expands(e.item) || !e.item.DisplayChildren {
+			continue
+		}
+		for _, child := range o.VisibleChildren(ctx, e.item) {
+			if (o.Budget.MaxDepth > 0 && e.level >= o.Budget.MaxDepth) || (o.Budget.MaxItems > 0 && len(granted) >= o.Budget.MaxItems) {
+				skipped++
+				continue
+			}
+			granted[child] = true
+			level := e.level + 1
+			if child == o.more {
+				// the items of the "more" branch count as top level items
+				level = e.level
+			}
+			queue = append(queue, entry{item: child, level: level})
+		}
+	}
+
+	o.granted, o.truncated = granted, skipped > 0
+
+	if report, ok := ctx.Value(budgetReportKey{}).(*BudgetReport); ok {
+		report.Truncated = report.Truncated || o.truncated
+		report.Rendered += len(granted)
+		report.Skipped += skipped
+	}
+}
@@ -0,0 +1,337 @@
+package renderer
+
+import (
+	clist "container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/gowool/menu"
+)
+
+var (
+	_ Renderer       = (*CachedRenderer)(nil)
+	_ WriterRenderer = (*CachedRenderer)(nil)
+	_ RenderCache    = (*MemoryRenderCache)(nil)
+)
+
+// RenderCache stores the output of the menus rendered by CachedRenderer, e.g. in memory or in a cache
+// shared by several instances of the application. The values can be evicted at any time.
+type RenderCache interface {
+	// Get returns the output stored under the key and whether it was found.
+	Get(ctx context.Context, key string) (string, bool)
+
+	// Set stores the output under the key.
+	Set(ctx context.Context, key, content string)
+}
+
+// CacheKeyFunc returns a part of the cache key of a render, see CachedRenderer.AddKeyFunc.
+type CacheKeyFunc func(ctx context.Context, item *menu.Item) string
+
+// CacheStats describes the renders of a CachedRenderer.
+type CacheStats struct {
+	// Hits is the number of renders served from the cache.
+	Hits uint64 `json:"hits"`
+	// Misses is the number of renders that had to run the underlying renderer.
+	Misses uint64 `json:"misses"`
+}
+
+// CachedRenderer is a Renderer caching the output of another renderer, since menus rarely change
+// and rendering a large tree on every request is wasted work.
+//
+// The output is cached under a key built from the hash of the tree, see menu.Item.Hash, the options given to Render
+// and the request URL carried by the context without its query string, see menu.WithRequestURL, so a changed tree
+// or another current page renders again. Everything else the output depends on must be added to the key with
+// AddKeyFunc, e.g. the language of the context, the permissions of the user checked by the Filters option
+// or the counts of count labels. The functions of the options, such as the Filters option, are not part of the key either.
+//
+// The hash of a tree is computed on its first render and reused for the same item, so a tree modified in place
+// must be invalidated, see Invalidate. SetTreeKey replaces the hash with a key supplied by the caller, e.g. the version
+// of the menus of a provider, for the trees built on every request. The options given to Render are encoded
+// on every render, the options of the underlying renderer are not part of the key.
+//
+// The output of failed renders is not cached, but the output of a Fallback option is, see WithFallback.
+// The options holding extras that cannot be encoded to JSON are rendered without cache.
+//
+// Example usage:
+//
+//	r := renderer.NewCachedRenderer(renderer.NewListRenderer(matcher), nil).
+//		AddKeyFunc(func(ctx context.Context, _ *menu.Item) string {
+//			return menu.LanguageFromContext(ctx).String()
+//		})
+type CachedRenderer struct {
+	renderer Renderer
+	cache    RenderCache
+
+	mu          sync.RWMutex
+	keyFuncs    []CacheKeyFunc
+	treeKey     CacheKeyFunc
+	hashes      map[*menu.Item]string
+	generation  uint64
+	generations map[string]uint64
+	hits        uint64
+	misses      uint64
+}
+
+// treeHashes is the number of tree hashes kept by a CachedRenderer.
+const treeHashes = 256
+
+// NewCachedRenderer creates a new CachedRenderer caching the output of the renderer in the cache,
+// a MemoryRenderCache of 1000 entries if nil.
+func NewCachedRenderer(renderer Renderer, cache RenderCache) *CachedRenderer {
+	if cache == nil {
+		cache = NewMemoryRenderCache(1000)
+	}
+	return &CachedRenderer{
+		renderer:    renderer,
+		cache:       cache,
+		hashes:      map[*menu.Item]string{},
+		generations: map[string]uint64{},
+	}
+}
+
+// AddKeyFunc adds functions returning parts of the cache key, for the parts of the context the output depends on.
+func (r *CachedRenderer) AddKeyFunc(fn ...CacheKeyFunc) *CachedRenderer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keyFuncs = append(r.keyFuncs, fn...)
+	return r
+}
+
+// SetTreeKey sets the function returning the part of the cache key identifying the rendered tree,
+// in place of its hash, e.g. the version of the menus of a provider.
+func (r *CachedRenderer) SetTreeKey(fn CacheKeyFunc) *CachedRenderer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.treeKey = fn
+	return r
+}
+
+// Invalidate discards the cached output of the menus with the given names, the names of their root items.
+// The entries are not removed from the cache, they are no longer read and left to its eviction.
+func (r *CachedRenderer) Invalidate(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range names {
+		r.generations[name]++
+	}
+	for item := range r.hashes {
+		if slices.Contains(names, item.Name) {
+			delete(r.hashes, item)
+		}
+	}
+}
+
+// InvalidateAll discards the cached output of all the menus, e.g. after the templates of a theme changed.
+func (r *CachedRenderer) InvalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	clear(r.hashes)
+}
+
+// Stats returns the hits and misses of the cache.
+func (r *CachedRenderer) Stats() CacheStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return CacheStats{Hits: r.hits, Misses: r.misses}
+}
+
+// Render returns the cached output of the item, rendering it with the underlying renderer on a cache miss.
+func (r *CachedRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	key, ok := r.key(ctx, item, options)
+	if !ok {
+		return r.renderer.Render(ctx, item, options...)
+	}
+
+	if content, ok := r.cache.Get(ctx, key); ok {
+		r.count(true)
+		return content, nil
+	}
+	r.count(false)
+
+	content, err := r.renderer.Render(ctx, item, options...)
+	if err != nil {
+		return "", err
+	}
+	r.cache.Set(ctx, key, content)
+	return content, nil
+}
+
+// RenderTo writes the cached output of the item to w, see Render. The output is buffered on a cache miss.
+func (r *CachedRenderer) RenderTo(ctx context.Context, w io.Writer, item *menu.Item, options ...Option) error {
+	content, err := r.Render(ctx, item, options...)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+func (r *CachedRenderer) count(hit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if hit {
+		r.hits++
+	} else {
+		r.misses++
+	}
+}
+
+// key returns the cache key of the render, or false if the options cannot be encoded.
+func (r *CachedRenderer) key(ctx context.Context, item *menu.Item, options []Option) (string, bool) {
+	var opts []byte
+	if len(options) > 0 {
+		var err error
+		if opts, err = json.Marshal(NewOptions(options...)); err != nil {
+			return "", false
+		}
+	}
+
+	r.mu.RLock()
+	generation, menuGeneration := r.generation, r.generations[item.Name]
+	keyFuncs := slices.Clone(r.keyFuncs)
+	r.mu.RUnlock()
+
+	h := sha256.New()
+	write := func(part string) {
+		_, _ = io.WriteString(h, strconv.Itoa(len(part)))
+		_, _ = io.WriteString(h, ":")
+		_, _ = io.WriteString(h, part)
+	}
+
+	write(strconv.FormatUint(generation, 10))
+	write(strconv.FormatUint(menuGeneration, 10))
+	write(r.treeHash(ctx, item))
+	write(string(opts))
+	if u, ok := menu.RequestURL(ctx); ok {
+		write((&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String())
+	} else {
+		write("")
+	}
+	for _, fn := range keyFuncs {
+		write(fn(ctx, item))
+	}
+
+	return item.Name + ":" + hex.EncodeToString(h.Sum(nil)), true
+}
+
+// treeHash returns the part of the cache key identifying the tree: the key of SetTreeKey, or the hash of the tree,
+// computed once per item.
+func (r *CachedRenderer) treeHash(ctx context.Context, item *menu.Item) string {
+	r.mu.RLock()
+	treeKey := r.treeKey
+	hash, ok := r.hashes[item]
+	r.mu.RUnlock()
+
+	if treeKey != nil {
+		return treeKey(ctx, item)
+	}
+	if ok {
+		return hash
+	}
+
+	hash = item.Hash()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.hashes) >= treeHashes {
+		clear(r.hashes)
+	}
+	r.hashes[item] = hash
+	return hash
+}
+
+type memoryRenderEntry struct {
+	key     string
+	content string
+}
+
+// MemoryRenderCache is an in-memory RenderCache evicting the least recently used entries.
+type MemoryRenderCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*clist.Element
+	lru     *clist.List
+}
+
+// NewMemoryRenderCache creates a new MemoryRenderCache holding at most size entries, unbounded if size is 0 or less.
+func NewMemoryRenderCache(size int) *MemoryRenderCache {
+	return &MemoryRenderCache{
+		size:    size,
+		entries: map[string]*clist.Element{},
+		lru:     clist.New(),
+	}
+}
+
+// Get returns the output stored under the key and whether it was found.
+func (c *MemoryRenderCache) Get(_ context.Context, key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*memoryRenderEntry).content, true
+}
+
+// Set stores the output under the key, evicting the least recently used entry if the cache is full.
+func (c *MemoryRenderCache) Set(_ context.Context, key, content string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryRenderEntry).content = content
+		c.lru.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&memoryRenderEntry{key: key, content: content})
+	if c.size > 0 && c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryRenderEntry).key)
+	}
+}
+
+// Len returns the number of entries of the cache.
+func (c *MemoryRenderCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// RoleSetKey returns a CacheKeyFunc adding the roles of the current user to the cache key, for the menus
+// personalized with menu.RoleFilter: only the roles the rendered tree is restricted to are part of the key,
+// see menu.RoleSetHash, so the users sharing a role set, and the users whose roles differ only by roles the menu
+// does not check, share the cached output.
+//
+// Example usage:
+//
+//	r := renderer.NewCachedRenderer(renderer.NewListRenderer(matcher, renderer.WithFilters(menu.RoleFilter())), nil).
+//		AddKeyFunc(renderer.RoleSetKey())
+func RoleSetKey() CacheKeyFunc {
+	return func(ctx context.Context, item *menu.Item) string {
+		roles := item.Roles()
+		if len(roles) == 0 {
+			return ""
+		}
+		return menu.RoleSetHash(ctx, roles...)
+	}
+}
@@ -0,0 +1,94 @@
+package renderer
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer/htmlutil"
+)
+
+// ItemClassFunc returns the classes added to the list element of an item at render time,
+// e.g. a status color or an "unread" marker computed for the current request.
+type ItemClassFunc func(ctx context.Context, item *menu.Item) []string
+
+// LinkAttrFunc returns the attributes added to the link of an item at render time, e.g. a data-count attribute.
+// The returned attributes override the link attributes of the item, except the class which is appended to them.
+type LinkAttrFunc func(ctx context.Context, item *menu.Item) map[string]any
+
+// ItemClasses returns the classes computed for the item by the ItemClassFunc option, or nil if it is not set.
+func (o *Options) ItemClasses(ctx context.Context, item *menu.Item) []string {
+	if o.ItemClassFunc == nil {
+		return nil
+	}
+	return o.ItemClassFunc(ctx, item)
+}
+
+// ItemClass returns the classes of ItemClasses joined with spaces, as used by templates.
+func (o *Options) ItemClass(ctx context.Context, item *menu.Item) string {
+	return strings.Join(o.ItemClasses(ctx, item), " ")
+}
+
+// LinkAttributes returns the link attributes of the item completed with the attributes computed
+// by the LinkAttrFunc option. The link attributes of the item are returned unchanged if it is not set.
+func (o *Options) LinkAttributes(ctx context.Context, item *menu.Item) map[string]any {
+	if o.LinkAttrFunc == nil {
+		return item.LinkAttributes
+	}
+
+	extra := o.LinkAttrFunc(ctx, item)
+	if len(extra) == 0 {
+		return item.LinkAttributes
+	}
+
+	attributes := maps.Clone(item.LinkAttributes)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	for name, value := range extra {
+		if name == "class" {
+			mergeClass(attributes, menu.ClassList(value)...)
+			continue
+		}
+		attributes[name] = value
+	}
+	return attributes
+}
+
+// mergeClass appends the classes to the class attribute of the attributes, which must be writable,
+// the classes already present keeping their position, see htmlutil.Classes.
+func mergeClass(attributes map[string]any, classes ...string) {
+	if class := htmlutil.Classes(append(menu.ClassList(attributes["class"]), classes...)); class != "" {
+		attributes["class"] = class
+	}
+}
+
+// SetItemClassFunc sets the function computing the classes of the list elements and returns a pointer
+// to the modified Options struct.
+func (o *Options) SetItemClassFunc(fn ItemClassFunc) *Options {
+	o.ItemClassFunc = fn
+	return o
+}
+
+// SetLinkAttrFunc sets the function computing the attributes of the links and returns a pointer
+// to the modified Options struct.
+func (o *Options) SetLinkAttrFunc(fn LinkAttrFunc) *Options {
+	o.LinkAttrFunc = fn
+	return o
+}
+
+// WithItemClassFunc returns an Option adding the classes computed by fn to the list element of every item,
+// so dynamic classes do not require to mutate the shared items per request.
+func WithItemClassFunc(fn ItemClassFunc) Option {
+	return func(options *Options) {
+		options.SetItemClassFunc(fn)
+	}
+}
+
+// WithLinkAttrFunc returns an Option adding the attributes computed by fn to the link of every item.
+func WithLinkAttrFunc(fn LinkAttrFunc) Option {
+	return func(options *Options) {
+		options.SetLinkAttrFunc(fn)
+	}
+}
@@ -0,0 +1,155 @@
+package renderer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gowool/menu"
+)
+
+var (
+	// ErrUnknownRenderer is returned when a render binding references an unregistered renderer type.
+	ErrUnknownRenderer = errors.New("unknown renderer")
+
+	// ErrUnknownPreset is returned when a render binding references an unregistered preset.
+	ErrUnknownPreset = errors.New("unknown preset")
+)
+
+// DefaultRenderer is the renderer type used by render bindings that do not name one.
+const DefaultRenderer = "list"
+
+// Factory creates a renderer with the given base options.
+type Factory func(options ...Option) Renderer
+
+// Factories returns the factories of the built-in renderers by type name: "list", "json", "jsonld", "text",
+// "select" and, when theme is not nil, "template".
+func Factories(matcher menu.Matcher, theme Theme) map[string]Factory {
+	factories := map[string]Factory{
+		"list": func(options ...Option) Renderer {
+			return NewListRenderer(matcher, options...)
+		},
+		"json": func(options ...Option) Renderer {
+			return NewJSONRenderer(matcher, options...)
+		},
+		"jsonld": func(options ...Option) Renderer {
+			return NewJSONLDRenderer(options...)
+		},
+		"text": func(options ...Option) Renderer {
+			return NewTextRenderer(matcher, options...)
+		},
+		"select": func(options ...Option) Renderer {
+			return NewSelectRenderer(matcher, options...)
+		},
+	}
+	if theme != nil {
+		factories["template"] = func(options ...Option) Renderer {
+			return NewTemplateRenderer(theme, matcher, options...)
+		}
+	}
+	return factories
+}
+
+// OptionsFromConfig returns the options described by a render binding: the options of its preset,
+// overridden by its options and its template. The options are validated like preset documents, see UnmarshalPreset.
+func OptionsFromConfig(cfg menu.RenderConfig) ([]Option, error) {
+	var options []Option
+	if cfg.Preset != "" {
+		preset, ok := Preset(cfg.Preset)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPreset, cfg.Preset)
+		}
+		options = append(options, preset...)
+	}
+
+	if len(cfg.Options) > 0 {
+		data, err := json.Marshal(cfg.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		o, err := decodePreset(data, NewOptions(options...))
+		if err != nil {
+			return nil, err
+		}
+		options = o.Slice()
+	}
+
+	if cfg.Template != "" {
+		options = append(options, WithTemplate(cfg.Template))
+	}
+
+	return options, nil
+}
+
+// MenuRenderer renders the menus of a menu.Provider by name. When the provider implements menu.RenderBinding,
+// every menu is rendered with the renderer type and options bound to it, e.g. "main navbar uses
+// the bootstrap5 preset with depth 2", otherwise with DefaultRenderer.
+// The renderer of a menu is built from its binding on its first render and reused afterwards.
+type MenuRenderer struct {
+	provider  menu.Provider
+	factories map[string]Factory
+	renderers *sync.Map
+}
+
+// NewMenuRenderer creates a new MenuRenderer using the given renderer factories by type name.
+func NewMenuRenderer(provider menu.Provider, factories map[string]Factory) MenuRenderer {
+	return MenuRenderer{
+		provider:  provider,
+		factories: factories,
+		renderers: &sync.Map{},
+	}
+}
+
+// RenderMenu loads the menu with the given name from the provider and renders it with its bound renderer.
+// The given options are applied on top of the bound options.
+func (r MenuRenderer) RenderMenu(ctx context.Context, name string, options ...Option) (string, error) {
+	item, err := r.provider.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	renderer, err := r.renderer(name)
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(ctx, item, options...)
+}
+
+// renderer returns the renderer bound to the menu with the given name, building it on first use.
+func (r MenuRenderer) renderer(name string) (Renderer, error) {
+	if r.renderers != nil {
+		if renderer, ok := r.renderers.Load(name); ok {
+			return renderer.(Renderer), nil
+		}
+	}
+
+	var cfg menu.RenderConfig
+	if binding, ok := r.provider.(menu.RenderBinding); ok {
+		cfg, _ = binding.RenderConfig(name)
+	}
+
+	typ := cfg.Renderer
+	if typ == "" {
+		typ = DefaultRenderer
+	}
+
+	factory, ok := r.factories[typ]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (menu %s)", ErrUnknownRenderer, typ, name)
+	}
+
+	base, err := OptionsFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("menu %s: %w", name, err)
+	}
+
+	renderer := factory(base...)
+	if r.renderers != nil {
+		stored, _ := r.renderers.LoadOrStore(name, renderer)
+		renderer = stored.(Renderer)
+	}
+	return renderer, nil
+}
@@ -0,0 +1,79 @@
+package renderer
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/internal"
+)
+
+var _ Renderer = (*DiffRenderer)(nil)
+
+// KeyFunc returns the key identifying a render, e.g. the menu name and the requested path.
+type KeyFunc func(ctx context.Context, item *menu.Item) string
+
+// PathKey is the default KeyFunc of DiffRenderer: the path of the rendered item.
+func PathKey(_ context.Context, item *menu.Item) string {
+	return item.PathString("/")
+}
+
+// DiffRenderer is a development wrapper comparing every render with the previous render for the same key
+// and logging a readable diff of the changed HTML, to debug menus changing unexpectedly between requests.
+// It keeps the last output of every key in memory, don't use it in production.
+type DiffRenderer struct {
+	renderer Renderer
+	logger   *slog.Logger
+	key      KeyFunc
+
+	mu   sync.Mutex
+	last map[string]string
+}
+
+// NewDiffRenderer wraps the renderer. A nil logger uses slog.Default and a nil key uses PathKey.
+func NewDiffRenderer(renderer Renderer, logger *slog.Logger, key KeyFunc) *DiffRenderer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if key == nil {
+		key = PathKey
+	}
+	return &DiffRenderer{
+		renderer: renderer,
+		logger:   logger,
+		key:      key,
+		last:     map[string]string{},
+	}
+}
+
+// Render renders the item with the wrapped renderer and logs the diff with the previous output for the same key.
+func (r *DiffRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	content, err := r.renderer.Render(ctx, item, options...)
+	if err != nil {
+		return content, err
+	}
+
+	key := r.key(ctx, item)
+
+	r.mu.Lock()
+	previous, ok := r.last[key]
+	r.last[key] = content
+	r.mu.Unlock()
+
+	if ok && previous != content {
+		diff := internal.DiffLines(internal.HTMLLines(previous), internal.HTMLLines(content))
+		r.logger.InfoContext(ctx, "menu render changed", "key", key, "diff", "\n"+strings.Join(diff, "\n"))
+	}
+
+	return content, nil
+}
+
+// Reset forgets the previous outputs.
+func (r *DiffRenderer) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.last = map[string]string{}
+}
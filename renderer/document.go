@@ -0,0 +1,157 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gowool/menu"
+)
+
+// ErrNotSerializable is returned when options holding functions or other values without a JSON representation
+// are exported as a preset document.
+var ErrNotSerializable = errors.New("render options not serializable")
+
+// MarshalPreset returns the preset document of the options: the JSON representation of the resulting Options,
+// using the JSON names of its fields. It fails with an error wrapping ErrNotSerializable when the options
+// set a Fallback, an ItemWrapper, callbacks, TemplateFuncs or Filters, or hold extras that cannot be represented in JSON, e.g. functions or channels.
+func MarshalPreset(options ...Option) ([]byte, error) {
+	o := NewOptions(options...)
+
+	var errs []error
+	if o.Fallback != nil {
+		errs = append(errs, fmt.Errorf("%w: fallback is a function", ErrNotSerializable))
+	}
+	if o.ItemWrapper != nil {
+		errs = append(errs, fmt.Errorf("%w: item wrapper is a function", ErrNotSerializable))
+	}
+	if o.ItemClassFunc != nil || o.LinkAttrFunc != nil {
+		errs = append(errs, fmt.Errorf("%w: item class and link attribute callbacks are functions", ErrNotSerializable))
+	}
+	if len(o.TemplateFuncs) > 0 {
+		errs = append(errs, fmt.Errorf("%w: template funcs are functions", ErrNotSerializable))
+	}
+	if len(o.Filters) > 0 {
+		errs = append(errs, fmt.Errorf("%w: filters are functions", ErrNotSerializable))
+	}
+	for key, value := range o.Extras {
+		if path := unserializable(reflect.ValueOf(value), ""); path != "" {
+			errs = append(errs, fmt.Errorf("%w: extra %q holds %T at %q", ErrNotSerializable, key, value, path))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(o)
+}
+
+// UnmarshalPreset decodes a preset document into the options reproducing it, see MarshalPreset.
+// Unknown fields and invalid values, such as negative depths or unknown dropdown modes,
+// are reported with menu.ErrInvalidConfig.
+func UnmarshalPreset(data []byte) ([]Option, error) {
+	o, err := decodePreset(data, NewOptions())
+	if err != nil {
+		return nil, err
+	}
+	return o.Slice(), nil
+}
+
+// RegisterPresetDocument registers the preset described by the document under the given name, see RegisterPreset.
+func RegisterPresetDocument(name string, data []byte) error {
+	options, err := UnmarshalPreset(data)
+	if err != nil {
+		return fmt.Errorf("preset %s: %w", name, err)
+	}
+	RegisterPreset(name, options...)
+	return nil
+}
+
+// PresetDocument returns the document of a registered preset, see MarshalPreset.
+func PresetDocument(name string) ([]byte, error) {
+	options, ok := Preset(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPreset, name)
+	}
+	return MarshalPreset(options...)
+}
+
+// decodePreset decodes the document over the options and validates the result.
+func decodePreset(data []byte, o *Options) (*Options, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(o); err != nil {
+		return nil, fmt.Errorf("%w: %w", menu.ErrInvalidConfig, err)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// validate checks the values of the options that cannot be checked by their types.
+func (o *Options) validate() error {
+	var errs []error
+	for name, depth := range map[string]*int{
+		"depth":                o.Depth,
+		"matching_depth":       o.MatchingDepth,
+		"current_branch_depth": o.CurrentBranchDepth,
+	} {
+		if depth != nil && *depth < 0 {
+			errs = append(errs, fmt.Errorf("%w: %s must not be negative, got %d", menu.ErrInvalidConfig, name, *depth))
+		}
+	}
+	switch o.DropdownMode {
+	case DropdownNone, DropdownHover, DropdownClick:
+	default:
+		errs = append(errs, fmt.Errorf("%w: unknown dropdown_mode %q", menu.ErrInvalidConfig, o.DropdownMode))
+	}
+	return errors.Join(errs...)
+}
+
+// unserializable returns the path of the first value without a JSON representation within v,
+// or an empty string if v can be represented in JSON.
+func unserializable(v reflect.Value, path string) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if path == "" {
+		path = "."
+	}
+
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return path
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return unserializable(v.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if p := unserializable(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); p != "" {
+				return p
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if p := unserializable(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key)); p != "" {
+				return p
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range v.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			if p := unserializable(v.Field(i), path+"."+field.Name); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
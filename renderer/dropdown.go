@@ -0,0 +1,120 @@
+package renderer
+
+import (
+	"context"
+	"maps"
+
+	"github.com/gowool/menu"
+)
+
+// DropdownMode selects how the branches of a menu open.
+type DropdownMode string
+
+const (
+	// DropdownNone emits no dropdown specific classes or attributes.
+	DropdownNone DropdownMode = ""
+	// DropdownHover marks branches with HoverClass for CSS-only menus opening on hover.
+	DropdownHover DropdownMode = "hover"
+	// DropdownClick marks the link of branches with ToggleClass and ToggleAttributes for menus toggled by JavaScript.
+	DropdownClick DropdownMode = "click"
+)
+
+// IsBranch checks if the children of the item are rendered.
+func (o *Options) IsBranch(ctx context.Context, item *menu.Item) bool {
+	return !o.IsStop() && o.Expands(item) && item.DisplayChildren && o.HasVisibleChildren(ctx, item)
+}
+
+// DropdownClass returns the class added to the list element of the item in the hover mode,
+// or an empty string if the item is not a branch.
+func (o *Options) DropdownClass(ctx context.Context, item *menu.Item) string {
+	if o.DropdownMode == DropdownHover && o.IsBranch(ctx, item) {
+		return o.HoverClass
+	}
+	return ""
+}
+
+// DropdownAttributes returns a copy of the given link or label attributes of the item completed with
+// the dropdown attributes: aria-haspopup for branches in both modes, and ToggleClass and ToggleAttributes
+// in the click mode, with aria-expanded set to true for the open branches, see IsOpen.
+// The attributes are returned unchanged if the item is not a branch.
+func (o *Options) DropdownAttributes(ctx context.Context, item *menu.Item, attributes map[string]any) map[string]any {
+	if o.DropdownMode == DropdownNone || !o.IsBranch(ctx, item) {
+		return attributes
+	}
+
+	attributes = maps.Clone(attributes)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	attributes["aria-haspopup"] = "true"
+
+	if o.DropdownMode == DropdownClick {
+		for name, value := range o.ToggleAttributes {
+			if _, ok := attributes[name]; !ok {
+				attributes[name] = value
+			}
+		}
+		if o.ToggleClass != "" {
+			mergeClass(attributes, o.ToggleClass)
+		}
+		if o.IsOpen(ctx, item) {
+			attributes["aria-expanded"] = "true"
+		}
+	}
+
+	return attributes
+}
+
+// SetDropdownMode sets the value of the DropdownMode field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetDropdownMode(mode DropdownMode) *Options {
+	o.DropdownMode = mode
+	return o
+}
+
+// SetHoverClass sets the value of the HoverClass field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetHoverClass(hoverClass string) *Options {
+	o.HoverClass = hoverClass
+	return o
+}
+
+// SetToggleClass sets the value of the ToggleClass field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetToggleClass(toggleClass string) *Options {
+	o.ToggleClass = toggleClass
+	return o
+}
+
+// SetToggleAttributes sets a copy of the toggle attributes in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetToggleAttributes(attributes map[string]any) *Options {
+	o.ToggleAttributes = maps.Clone(attributes)
+	return o
+}
+
+// WithDropdownMode is a function that returns an Option for setting how the branches of a menu open,
+// so one theme supports both hover and click interaction modes.
+func WithDropdownMode(mode DropdownMode) Option {
+	return func(options *Options) {
+		options.SetDropdownMode(mode)
+	}
+}
+
+// WithHoverClass is a function that returns an Option for setting the class added to branches in the hover mode.
+func WithHoverClass(hoverClass string) Option {
+	return func(options *Options) {
+		options.SetHoverClass(hoverClass)
+	}
+}
+
+// WithToggleClass is a function that returns an Option for setting the class added to the link of branches in the click mode.
+func WithToggleClass(toggleClass string) Option {
+	return func(options *Options) {
+		options.SetToggleClass(toggleClass)
+	}
+}
+
+// WithToggleAttributes is a function that returns an Option for setting the attributes added to the link of branches
+// in the click mode, e.g. {"data-bs-toggle": "dropdown", "role": "button", "aria-expanded": "false"}.
+func WithToggleAttributes(attributes map[string]any) Option {
+	return func(options *Options) {
+		options.SetToggleAttributes(attributes)
+	}
+}
@@ -0,0 +1,49 @@
+package renderer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gowool/menu"
+)
+
+// DryRun returns the paths of the items that ListRenderer and the default templates of TemplateRenderer would render
+// with the same matcher and options, in rendering order, without producing any HTML. The paths are the names of
+// the items from the root joined with "/", e.g. "main/blog/news".
+//
+// It applies the same rules as the renderers: the Depth, CurrentBranchDepth, Channel, Filters and Budget options
+// and the Display and DisplayChildren fields of the items, e.g. for an admin "preview visibility as role X" feature:
+//
+//	paths := renderer.DryRun(ctx, matcher, root, renderer.WithFilters(roleFilter("editor")))
+func DryRun(ctx context.Context, matcher menu.Matcher, item *menu.Item, options ...Option) []string {
+	ctx = menu.WithMatcher(ctx, matcher)
+	opts := NewOptions(options...)
+	if opts.Channel != "" {
+		ctx = menu.ContextWithChannel(ctx, opts.Channel)
+	}
+	item = opts.prepare(ctx, matcher, item)
+
+	var paths []string
+	dryRun(ctx, item, opts, &paths)
+
+	if opts.ClearMatcher {
+		matcher.Clear()
+	}
+
+	return paths
+}
+
+// dryRun collects the paths of the children of the item like ListRenderer.renderList renders them,
+// with the LevelOptions of their level applied.
+func dryRun(ctx context.Context, item *menu.Item, options *Options, paths *[]string) {
+	if !options.IsBranch(ctx, item) {
+		return
+	}
+
+	options = options.SubDepth().SubMatchingDepth()
+
+	for _, child := range options.VisibleChildren(ctx, item) {
+		*paths = append(*paths, strings.Join(child.Path(), "/"))
+		dryRun(ctx, child, options.ForItem(child), paths)
+	}
+}
@@ -0,0 +1,32 @@
+package renderer
+
+import "github.com/gowool/menu/renderer/htmlutil"
+
+// AttributeEncoder encodes the value of an HTML attribute, the result is written between double quotes.
+// It is an alias of htmlutil.Encoder, the encoders are shared with the htmlutil package.
+type AttributeEncoder = htmlutil.Encoder
+
+// Built-in attribute encoders.
+var (
+	// HTMLEncoder escapes the HTML special characters, it is used by the attributes without a registered encoder.
+	HTMLEncoder AttributeEncoder = htmlutil.EncodeHTML
+	// URLEncoder percent-encodes the characters that are not valid in a URL before escaping the HTML special characters.
+	// It is registered for href, src, action, formaction, cite and poster.
+	URLEncoder AttributeEncoder = htmlutil.EncodeURL
+	// SrcsetEncoder applies URLEncoder to every image candidate of a srcset value. It is registered for srcset.
+	SrcsetEncoder AttributeEncoder = htmlutil.EncodeSrcset
+	// CSSEncoder rejects the style values able to load resources or run code, e.g. url() or expression(),
+	// replacing them by "ZgotmplZ" like html/template does. It is registered for style.
+	CSSEncoder AttributeEncoder = htmlutil.EncodeCSS
+)
+
+// RegisterAttributeEncoder registers the encoder used for the attribute with the given name, case-insensitively,
+// by all the renderers and by the attributes template function. A nil encoder restores HTMLEncoder.
+// It is meant to be called during initialization.
+//
+// Example usage:
+//
+//	renderer.RegisterAttributeEncoder("data-href", renderer.URLEncoder)
+func RegisterAttributeEncoder(name string, encoder AttributeEncoder) {
+	htmlutil.RegisterEncoder(name, encoder)
+}
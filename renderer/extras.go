@@ -0,0 +1,140 @@
+package renderer
+
+import (
+	"html/template"
+	"reflect"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer/htmlutil"
+)
+
+// OptionExtras is the registry the built-in option extras and the ones created by DefineOptionExtra are declared in.
+// It is distinct from menu.DefaultExtras since option extras are stored in Options.Extras, not in menu.Item.Extras.
+var OptionExtras = menu.NewExtrasRegistry()
+
+// Built-in option extras. Prefer these typed accessors to WithExtra and Options.Extra with string keys,
+// so typos are caught by the compiler instead of silently falling back to defaults.
+// The built-in option extras are namespaced, e.g. "render.template", and still read from their former bare keys,
+// e.g. "template".
+var (
+	// TemplateExtra holds the name of the template rendered by TemplateRenderer.
+	TemplateExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "template"), MenuTemplate, "template")
+
+	// CompressedExtra disables the indentation and new lines of the ListRenderer output.
+	//
+	// Deprecated: use the Compressed option, see WithCompressed. The extra is still honored by ListRenderer.
+	CompressedExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "compressed"), false, "compressed")
+
+	// ListElementExtra holds the element of the lists, "ul" or "ol", see Options.ListElement.
+	ListElementExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "list_element"), "ul", "list_element")
+)
+
+// OptionExtra is a typed accessor for a value stored in Options.Extras.
+type OptionExtra[T any] struct {
+	key     string
+	aliases []string
+	def     T
+}
+
+// NewOptionExtra returns a typed accessor for the extra with the given key and default value.
+// The extra is not declared in OptionExtras, see DefineOptionExtra.
+func NewOptionExtra[T any](key string, def T, aliases ...string) OptionExtra[T] {
+	return OptionExtra[T]{key: key, aliases: aliases, def: def}
+}
+
+// DefineOptionExtra declares an option extra of type T in OptionExtras and returns its typed accessor.
+// The extra is also read from the aliases, e.g. the key it had before being renamed.
+// It is meant to be called from package-level variable declarations and panics on conflicting declarations.
+func DefineOptionExtra[T any](owner, key string, def T, aliases ...string) OptionExtra[T] {
+	if err := OptionExtras.Register(menu.ExtraInfo{
+		Key:     key,
+		Aliases: aliases,
+		Owner:   owner,
+		Type:    reflect.TypeFor[T](),
+		Default: def,
+	}); err != nil {
+		panic(err)
+	}
+	return NewOptionExtra(key, def, aliases...)
+}
+
+// Key returns the key of the extra.
+func (e OptionExtra[T]) Key() string {
+	return e.key
+}
+
+// Get returns the value of the extra, read from its key or else from the first of its aliases the options hold,
+// or the default value if the options do not hold the extra or hold a value of another type.
+func (e OptionExtra[T]) Get(options *Options) T {
+	if value, ok := options.Extras[e.key]; ok {
+		if value, ok := value.(T); ok {
+			return value
+		}
+		return e.def
+	}
+	for _, alias := range e.aliases {
+		if value, ok := options.Extras[alias]; ok {
+			if value, ok := value.(T); ok {
+				return value
+			}
+			return e.def
+		}
+	}
+	return e.def
+}
+
+// Set stores the value of the extra in the options, under its key, removing the values stored under its aliases.
+func (e OptionExtra[T]) Set(options *Options, value T) {
+	if options.Extras == nil {
+		options.Extras = map[string]any{}
+	}
+	options.Extras[e.key] = value
+	for _, alias := range e.aliases {
+		delete(options.Extras, alias)
+	}
+}
+
+// Option returns an Option storing the value of the extra.
+func (e OptionExtra[T]) Option(value T) Option {
+	return func(options *Options) {
+		e.Set(options, value)
+	}
+}
+
+// WithTemplate returns an Option setting the template rendered by TemplateRenderer.
+func WithTemplate(template string) Option {
+	return TemplateExtra.Option(template)
+}
+
+// WithListElement returns an Option setting the element of the lists, "ul" or "ol" for numbered navigation
+// such as checkout steps or tutorial chapters. The start and reversed attributes of an ordered list
+// are set with the ChildrenAttributes of the items.
+func WithListElement(element string) Option {
+	return ListElementExtra.Option(element)
+}
+
+// ListElement returns the element of the lists, "ol" if ListElementExtra is "ol", "ul" otherwise.
+func (o *Options) ListElement() string {
+	if ListElementExtra.Get(o) == "ol" {
+		return "ol"
+	}
+	return "ul"
+}
+
+// BadgeClassExtra holds the class of the <span> element rendering the badge of the items, see Options.Badge.
+var BadgeClassExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "badge_class"), "menu-badge", "badge_class")
+
+// WithBadgeClass returns an Option setting the class of the badge of the items.
+func WithBadgeClass(class string) Option {
+	return BadgeClassExtra.Option(class)
+}
+
+// Badge returns the badge of the item rendered after its label, e.g. <span class="menu-badge">New</span>,
+// or an empty string if the item has no menu.BadgeExtra.
+func (o *Options) Badge(item *menu.Item) template.HTML {
+	badge := menu.BadgeExtra.Get(item)
+	if badge == "" {
+		return ""
+	}
+	return template.HTML(`<span` + htmlutil.Attributes(map[string]any{"class": BadgeClassExtra.Get(o)}) + `>` + htmlutil.EncodeHTML(badge) + `</span>`)
+}
@@ -0,0 +1,41 @@
+package renderer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gowool/menu"
+)
+
+func TestOptionExtraAliases(t *testing.T) {
+	options := NewOptions(WithExtra("bootstrap5_collapse_id", "legacy"))
+
+	if got := Bootstrap5CollapseIDExtra.Get(options); got != "legacy" {
+		t.Errorf("Get() = %q, want the value of the alias", got)
+	}
+	if got := options.Extra("render.bootstrap5_collapse_id"); got != "legacy" {
+		t.Errorf("Extra() = %v, want the value of the alias", got)
+	}
+
+	Bootstrap5CollapseIDExtra.Set(options, "main")
+	if _, ok := options.Extras["bootstrap5_collapse_id"]; ok {
+		t.Error("Set() kept the value of the alias")
+	}
+	if got := options.Extra("bootstrap5_collapse_id"); got != "main" {
+		t.Errorf("Extra() = %v, want the value of the key", got)
+	}
+}
+
+func TestDefineOptionExtraConflict(t *testing.T) {
+	for _, key := range []string{"render.template", "template"} {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, menu.ErrExtraConflict) {
+					t.Errorf("DefineOptionExtra(%q) panicked with %v, want ErrExtraConflict", key, err)
+				}
+			}()
+			DefineOptionExtra("plugin", key, 0)
+		}()
+	}
+}
@@ -0,0 +1,29 @@
+package renderer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gowool/menu"
+)
+
+// FallbackFunc returns the content rendered in place of a menu whose rendering failed with err.
+type FallbackFunc func(ctx context.Context, item *menu.Item, err error) string
+
+// EmptyFallback renders nothing in place of a failed menu.
+func EmptyFallback(context.Context, *menu.Item, error) string {
+	return ""
+}
+
+// EmptyNavFallback renders an empty <nav> element in place of a failed menu, keeping the page layout intact.
+func EmptyNavFallback(context.Context, *menu.Item, error) string {
+	return "<nav></nav>"
+}
+
+// CommentFallback renders an HTML comment naming the failed menu and the error.
+// Useful in development; the error message may leak internals, so avoid it in production.
+func CommentFallback(_ context.Context, item *menu.Item, err error) string {
+	// "--" terminates a comment early in some parsers
+	text := strings.ReplaceAll(item.String()+": "+err.Error(), "--", "- -")
+	return "<!-- menu " + strings.ReplaceAll(text, ">", "&gt;") + " -->"
+}
@@ -0,0 +1,161 @@
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gowool/menu/views"
+)
+
+var (
+	_ Theme       = (*FSTheme)(nil)
+	_ WriterTheme = (*FSTheme)(nil)
+)
+
+// FSTheme is a Theme backed by html/template that loads its templates from a chain of file systems, e.g. the
+// templates of a project overriding a few of the built-in ones. A template is looked up in the file systems in
+// order, the templates embedded in the views package being the last fallback, so a project only ships the
+// templates it customizes: a "menu/item.html" file replaces the "@menu/item.html" template of every built-in
+// template set, and a "mega/menu.html" file adds a "@mega/menu.html" template.
+//
+// With auto reload enabled, see SetAutoReload, the templates are parsed again when a file of the chain changed,
+// so templates can be edited without restarting the application during development.
+//
+// Example usage:
+//
+//	theme, err := renderer.NewDirTheme(nil, "templates/menu")
+//	if err != nil {
+//		return err
+//	}
+//	theme.SetAutoReload(debug)
+type FSTheme struct {
+	funcs template.FuncMap
+	fsys  []fs.FS
+
+	mu         sync.RWMutex
+	t          *template.Template
+	autoReload bool
+	stamp      string
+}
+
+// NewFSTheme parses the "*/*.html" templates of the file systems, falling back to the templates embedded
+// in the views package, with FuncMap and the given funcs, see NewHTMLTheme.
+// The templates are registered under their path prefixed with "@", e.g. MenuTemplate.
+func NewFSTheme(funcs template.FuncMap, fsys ...fs.FS) (*FSTheme, error) {
+	t := &FSTheme{
+		funcs: funcs,
+		fsys:  append(slices.Clone(fsys), views.FS),
+	}
+	if err := t.Reload(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// NewDirTheme is like NewFSTheme with the templates of the directory, e.g. "templates/menu" holding
+// a "menu/item.html" file.
+func NewDirTheme(funcs template.FuncMap, dir string) (*FSTheme, error) {
+	return NewFSTheme(funcs, os.DirFS(dir))
+}
+
+// SetAutoReload enables or disables the parsing of the templates when a file of the chain changed,
+// detected by the names, sizes and modification times of the files. It is meant for development,
+// since every render then lists the files of the chain. It returns the theme.
+func (t *FSTheme) SetAutoReload(autoReload bool) *FSTheme {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.autoReload = autoReload
+	return t
+}
+
+// Reload parses the templates of the file systems again. The current templates are kept if parsing fails.
+func (t *FSTheme) Reload() error {
+	stamp, err := t.fingerprint()
+	if err != nil {
+		return err
+	}
+
+	tpl, err := parseTemplates(t.funcs, t.fsys...)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.t, t.stamp = tpl, stamp
+	return nil
+}
+
+// HTML executes the named template with the given data and returns the result.
+// The output is buffered, nothing is returned if the execution fails midway.
+func (t *FSTheme) HTML(ctx context.Context, name string, data any) (string, error) {
+	var b strings.Builder
+	if err := t.HTMLTo(ctx, &b, name, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// HTMLTo executes the named template with the given data and writes the result to w as it is generated.
+func (t *FSTheme) HTMLTo(_ context.Context, w io.Writer, name string, data any) error {
+	tpl, err := t.template()
+	if err != nil {
+		return err
+	}
+	return tpl.ExecuteTemplate(w, name, data)
+}
+
+// template returns the parsed templates, parsed again first if auto reload is enabled and a file changed.
+func (t *FSTheme) template() (*template.Template, error) {
+	t.mu.RLock()
+	tpl, autoReload, stamp := t.t, t.autoReload, t.stamp
+	t.mu.RUnlock()
+
+	if !autoReload {
+		return tpl, nil
+	}
+
+	current, err := t.fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	if current == stamp {
+		return tpl, nil
+	}
+
+	if err = t.Reload(); err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t, nil
+}
+
+// fingerprint lists the names, sizes and modification times of the template files of the chain.
+func (t *FSTheme) fingerprint() (string, error) {
+	var b strings.Builder
+	for i, fsys := range t.fsys {
+		files, err := fs.Glob(fsys, "*/*.html")
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			info, err := fs.Stat(fsys, file)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(fmt.Sprintf("%d:%s:%d:%d\n", i, file, info.Size(), info.ModTime().UnixNano()))
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,32 @@
+package renderer
+
+import (
+	"html/template"
+	"maps"
+)
+
+// SetTemplateFuncs replaces the functions exposed to the templates of TemplateRenderer as .Funcs
+// and returns the pointer to the Options struct.
+func (o *Options) SetTemplateFuncs(funcs template.FuncMap) *Options {
+	o.TemplateFuncs = maps.Clone(funcs)
+	return o
+}
+
+// WithTemplateFuncs is a function that returns an Option adding functions exposed to the templates of
+// TemplateRenderer as .Funcs, e.g. translations, URL builders or icon helpers depending on the request,
+// without forking the Theme. Unlike the functions given to NewHTMLTheme, which are bound when the templates
+// are parsed, they can change from one render to the next and are called with the call builtin:
+//
+//	r.Render(ctx, root, renderer.WithTemplateFuncs(template.FuncMap{"t": translator(locale)}))
+//
+//	{{call .Funcs.t "menu.title"}}
+func WithTemplateFuncs(funcs template.FuncMap) Option {
+	return func(options *Options) {
+		merged := maps.Clone(options.TemplateFuncs)
+		if merged == nil {
+			merged = template.FuncMap{}
+		}
+		maps.Copy(merged, funcs)
+		options.TemplateFuncs = merged
+	}
+}
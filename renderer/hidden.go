@@ -0,0 +1,80 @@
+package renderer
+
+import "github.com/gowool/menu"
+
+// HiddenMode selects how the items hidden with Display set to false are rendered, see the Hidden option.
+// It applies the same way to ListRenderer, the templates and the other renderers, which all go through
+// Options.Children and Options.IsVisible. The items hidden by the Channel, the Filters or the Budget options
+// are always skipped with their descendants, e.g. for permissions.
+type HiddenMode string
+
+const (
+	// HiddenSkip skips the hidden items and their descendants. The rendered item itself is never rendered,
+	// only its children, so its children are rendered even if it is hidden.
+	HiddenSkip HiddenMode = ""
+	// HiddenSkipRoot skips the hidden items and their descendants, the rendered item included:
+	// nothing is rendered from a hidden rendered item, but the skip link, the brand and the nav options.
+	HiddenSkipRoot HiddenMode = "skip_root"
+	// HiddenChildren renders the children of the hidden items in their place, in the list of their parent,
+	// e.g. for the items grouping others in the tree only. The children of a hidden rendered item are rendered.
+	// The hidden items whose DisplayChildren is false are skipped with their descendants.
+	HiddenChildren HiddenMode = "children"
+)
+
+// liftHidden returns the children with the hidden ones replaced with their own children, recursively.
+func liftHidden(children []*menu.Item) []*menu.Item {
+	if !containsHidden(children) {
+		return children
+	}
+
+	lifted := make([]*menu.Item, 0, len(children))
+	for _, child := range children {
+		switch {
+		case child.Display:
+			lifted = append(lifted, child)
+		case child.DisplayChildren:
+			lifted = append(lifted, liftHidden(child.Children)...)
+		}
+	}
+	return lifted
+}
+
+func containsHidden(children []*menu.Item) bool {
+	for _, child := range children {
+		if !child.Display {
+			return true
+		}
+	}
+	return false
+}
+
+// renderParent returns the item whose list holds the item when rendered: its parent, or the closest ancestor
+// that is not hidden with the HiddenChildren mode of the Hidden option.
+func (o *Options) renderParent(item *menu.Item) *menu.Item {
+	parent := item.Parent
+	if o.Hidden == HiddenChildren {
+		for parent != nil && !parent.Display && parent != o.root && parent.Parent != nil {
+			parent = parent.Parent
+		}
+	}
+	return parent
+}
+
+// skipsRoot checks if nothing is rendered from the rendered item, hidden with the HiddenSkipRoot mode.
+func (o *Options) skipsRoot() bool {
+	return o.Hidden == HiddenSkipRoot && o.root != nil && !o.root.Display
+}
+
+// SetHidden sets the value of the Hidden field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetHidden(mode HiddenMode) *Options {
+	o.Hidden = mode
+	return o
+}
+
+// WithHidden is a function that returns an Option for selecting how the hidden items are rendered,
+// e.g. WithHidden(HiddenChildren) for the items that only group others, see HiddenMode.
+func WithHidden(mode HiddenMode) Option {
+	return func(options *Options) {
+		options.SetHidden(mode)
+	}
+}
@@ -0,0 +1,9 @@
+// Package htmlutil provides the HTML helpers used by the built-in renderers and themes to render attributes
+// and classes, so custom renderers and themes produce attributes with the exact same semantics: the same
+// encoding per attribute, e.g. URLs in href and src, the same omitted values and the same order.
+//
+// Example usage:
+//
+//	attributes := map[string]any{"class": htmlutil.Classes([]string{"nav-link", active}), "href": item.URI}
+//	html := fmt.Sprintf("<a%s>%s</a>", htmlutil.Attributes(attributes), htmlutil.EncodeHTML(item.Label))
+package htmlutil
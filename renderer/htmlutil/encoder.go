@@ -0,0 +1,118 @@
+package htmlutil
+
+import (
+	"html"
+	"strings"
+	"sync"
+)
+
+// Encoder encodes the value of an attribute, the result is written between double quotes.
+type Encoder func(value string) string
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{
+		"href":       EncodeURL,
+		"src":        EncodeURL,
+		"action":     EncodeURL,
+		"formaction": EncodeURL,
+		"cite":       EncodeURL,
+		"poster":     EncodeURL,
+		"srcset":     EncodeSrcset,
+		"style":      EncodeCSS,
+	}
+)
+
+// RegisterEncoder registers the encoder of the attribute with the given name, case-insensitively,
+// used by EncodeAttribute and so by all the renderers and templates. A nil encoder restores EncodeHTML.
+// It is meant to be called during initialization.
+func RegisterEncoder(name string, encoder Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	name = strings.ToLower(name)
+	if encoder == nil {
+		delete(encoders, name)
+		return
+	}
+	encoders[name] = encoder
+}
+
+// EncodeAttribute encodes the value with the encoder of the attribute, EncodeHTML if none is registered.
+func EncodeAttribute(name, value string) string {
+	encodersMu.RLock()
+	encoder, ok := encoders[strings.ToLower(name)]
+	encodersMu.RUnlock()
+
+	if !ok {
+		return EncodeHTML(value)
+	}
+	return encoder(value)
+}
+
+// EncodeHTML escapes the HTML special characters of the value.
+func EncodeHTML(value string) string {
+	return html.EscapeString(value)
+}
+
+// EncodeURL percent-encodes the characters that are not valid in a URL, such as spaces and quotes,
+// keeping the existing escapes, then escapes the HTML special characters.
+func EncodeURL(value string) string {
+	return html.EscapeString(NormalizeURL(value))
+}
+
+// NormalizeURL percent-encodes the characters of the value that are neither unreserved nor reserved
+// characters of RFC 3986, the percent sign excepted.
+func NormalizeURL(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isURLChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte("0123456789ABCDEF"[c>>4])
+		b.WriteByte("0123456789ABCDEF"[c&15])
+	}
+	return b.String()
+}
+
+func isURLChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("-._~:/?#[]@!$&'()*+,;=%", c) >= 0
+}
+
+// EncodeSrcset normalizes the URL of every image candidate of a srcset value, keeping the descriptors,
+// then escapes the HTML special characters.
+func EncodeSrcset(value string) string {
+	candidates := strings.Split(value, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = NormalizeURL(fields[0])
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return html.EscapeString(strings.Join(candidates, ", "))
+}
+
+// UnsafeCSS replaces the style values rejected by EncodeCSS, like html/template does.
+const UnsafeCSS = "ZgotmplZ"
+
+// EncodeCSS escapes the HTML special characters of a style value, unless it contains constructs
+// that can load resources or run code, such as url(), expression(), @import, escapes, comments or markup.
+// Such values are replaced by UnsafeCSS.
+func EncodeCSS(value string) string {
+	lower := strings.ToLower(value)
+	for _, unsafe := range []string{"url(", "expression(", "@import", "javascript:", "\\", "/*", "*/", "<", ">", "`"} {
+		if strings.Contains(lower, unsafe) {
+			return UnsafeCSS
+		}
+	}
+	return html.EscapeString(value)
+}
@@ -0,0 +1,46 @@
+package htmlutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodeAttribute(t *testing.T) {
+	tests := []struct {
+		name, value, want string
+	}{
+		{"title", `<a href="x">`, `&lt;a href=&#34;x&#34;&gt;`},
+		{"HREF", "/search?q=a b&c=%20", "/search?q=a%20b&amp;c=%20"},
+		{"src", "/é", "/%C3%A9"},
+		{"srcset", `/a"b.png 1x,  /c.png   2x`, "/a%22b.png 1x, /c.png 2x"},
+		{"style", "color: red; width: 10px", "color: red; width: 10px"},
+		{"style", "background: URL(/x.png)", UnsafeCSS},
+		{"style", `content: "\41"`, UnsafeCSS},
+		{"style", "a</style><script>", UnsafeCSS},
+	}
+	for _, tt := range tests {
+		if got := EncodeAttribute(tt.name, tt.value); got != tt.want {
+			t.Errorf("EncodeAttribute(%q, %q) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	RegisterEncoder("Data-Src", EncodeURL)
+	t.Cleanup(func() { RegisterEncoder("data-src", nil) })
+
+	if got, want := EncodeAttribute("data-src", "/a b"), "/a%20b"; got != want {
+		t.Errorf("EncodeAttribute() = %q, want %q", got, want)
+	}
+
+	RegisterEncoder("data-src", nil)
+	if got, want := EncodeAttribute("data-src", "/a b"), "/a b"; got != want {
+		t.Errorf("EncodeAttribute() after removing the encoder = %q, want %q", got, want)
+	}
+
+	RegisterEncoder("data-upper", strings.ToUpper)
+	t.Cleanup(func() { RegisterEncoder("data-upper", nil) })
+	if got, want := Attribute("data-upper", "abc"), `data-upper="ABC"`; got != want {
+		t.Errorf("Attribute() = %s, want %s", got, want)
+	}
+}
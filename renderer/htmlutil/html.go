@@ -0,0 +1,117 @@
+package htmlutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Attribute renders the attribute with the given name and value, encoded with the encoder of the attribute,
+// see EncodeAttribute, or an empty string if the attribute is omitted:
+//   - a nil value, a false bool and an empty class are omitted
+//   - the classes of a class attribute are separated by single spaces, without duplicates
+//   - a true bool renders a boolean attribute, e.g. hidden="hidden"
+//   - a []string or a []any renders its values separated by spaces, without duplicates, e.g. for class or rel
+//   - the other values are formatted with fmt.Sprint
+func Attribute(name string, value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if !v {
+			return ""
+		}
+		value = name
+	case string:
+		if name == "class" {
+			if value = Classes([]string{v}); value == "" {
+				return ""
+			}
+		}
+	case []string:
+		value = Classes(v)
+		if value == "" {
+			return ""
+		}
+	case []any:
+		value = ClassesAny(v)
+		if value == "" {
+			return ""
+		}
+	}
+	return fmt.Sprintf(`%s="%s"`, name, EncodeAttribute(name, fmt.Sprint(value)))
+}
+
+// Attributes renders the attributes sorted by name, each preceded by a space, so the output is the same
+// from one render to the next. The omitted attributes are skipped, see Attribute.
+//
+// Example usage:
+//
+//	fmt.Sprintf("<li%s>", htmlutil.Attributes(map[string]any{"id": "blog", "class": "active"}))
+//	// <li class="active" id="blog">
+func Attributes(attributes map[string]any) string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return AttributesInOrder(names, attributes)
+}
+
+// AttributesInOrder renders the attributes in the order of the names, each preceded by a space,
+// e.g. to keep the order chosen by the author of the attributes, see menu.Attributes.
+// The names missing from the attributes and the omitted attributes are skipped, see Attribute.
+func AttributesInOrder(names []string, attributes map[string]any) string {
+	var b strings.Builder
+	for _, name := range names {
+		value, ok := attributes[name]
+		if !ok {
+			continue
+		}
+		if attribute := Attribute(name, value); attribute != "" {
+			b.WriteRune(' ')
+			b.WriteString(attribute)
+		}
+	}
+	return b.String()
+}
+
+// Classes joins the classes with spaces, each element possibly holding several classes separated by spaces.
+// The duplicates are removed, the first occurrence of a class keeping its position, so the classes of the author
+// of a menu come before the classes computed by the renderers, e.g. "nav-item active".
+func Classes(classes []string) string {
+	unique := make([]string, 0, len(classes))
+	for _, class := range classes {
+		for _, c := range strings.Fields(class) {
+			if !slices.Contains(unique, c) {
+				unique = append(unique, c)
+			}
+		}
+	}
+	return strings.Join(unique, " ")
+}
+
+// ClassesAny joins the classes like Classes, flattening the []string and []any values, e.g. the class
+// attribute of an item decoded from JSON, and formatting the other values with fmt.Sprint.
+// Nil values are skipped, e.g. the missing class of an item in a template.
+func ClassesAny(classes []any) string {
+	return Classes(classStrings(nil, classes))
+}
+
+func classStrings(dst []string, classes []any) []string {
+	for _, class := range classes {
+		switch c := class.(type) {
+		case nil:
+		case string:
+			dst = append(dst, c)
+		case []string:
+			dst = append(dst, c...)
+		case []any:
+			dst = classStrings(dst, c)
+		default:
+			dst = append(dst, fmt.Sprint(c))
+		}
+	}
+	return dst
+}
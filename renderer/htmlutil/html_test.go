@@ -0,0 +1,44 @@
+package htmlutil
+
+import "testing"
+
+func TestAttribute(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"title", nil, ""},
+		{"hidden", false, ""},
+		{"hidden", true, `hidden="hidden"`},
+		{"class", "  ", ""},
+		{"class", " nav  nav active ", `class="nav active"`},
+		{"class", []string{"nav", "nav-item nav"}, `class="nav nav-item"`},
+		{"rel", []any{"nofollow", []string{"noopener", "nofollow"}, nil}, `rel="nofollow noopener"`},
+		{"data-count", 3, `data-count="3"`},
+		{"title", `"a" & <b>`, `title="&#34;a&#34; &amp; &lt;b&gt;"`},
+		{"href", "/a b?q=\"x\"", `href="/a%20b?q=%22x%22"`},
+	}
+	for _, tt := range tests {
+		if got := Attribute(tt.name, tt.value); got != tt.want {
+			t.Errorf("Attribute(%q, %#v) = %s, want %s", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	attributes := map[string]any{"id": "blog", "class": "active", "hidden": false, "data-x": 1}
+
+	if got, want := Attributes(attributes), ` class="active" data-x="1" id="blog"`; got != want {
+		t.Errorf("Attributes() = %s, want %s", got, want)
+	}
+	if got, want := AttributesInOrder([]string{"id", "missing", "hidden", "class"}, attributes), ` id="blog" class="active"`; got != want {
+		t.Errorf("AttributesInOrder() = %s, want %s", got, want)
+	}
+}
+
+func TestClassesAny(t *testing.T) {
+	if got, want := ClassesAny([]any{"nav-item", nil, []any{"active", []string{"nav-item", "first"}}, 42}), "nav-item active first 42"; got != want {
+		t.Errorf("ClassesAny() = %q, want %q", got, want)
+	}
+}
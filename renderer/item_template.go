@@ -0,0 +1,44 @@
+package renderer
+
+import (
+	"maps"
+
+	"github.com/gowool/menu"
+)
+
+// ItemTemplateExtra holds the name of the template rendering the list element of the item with TemplateRenderer,
+// e.g. "@mega/item.html" for a mega menu column, in place of the item template of the template set.
+// It takes precedence over the LevelTemplates option.
+var ItemTemplateExtra = menu.DefineExtra("renderer", menu.ExtraKey("render", "template"), "", "template")
+
+// WithItemTemplate is a function that returns a menu.Option setting the ItemTemplateExtra of an Item.
+func WithItemTemplate(name string) menu.Option {
+	return ItemTemplateExtra.Option(name)
+}
+
+// ItemTemplate returns the name of the template overriding the item template of the template set for the item:
+// its ItemTemplateExtra, or the template of its level in the LevelTemplates option, or an empty string.
+// The templates receive the same data as the item template they replace, and are expected to render
+// the list element of the item and, through "@menu/list.html" or their own markup, its children.
+// The overrides apply to the default menu templates and to the Tailwind templates.
+func (o *Options) ItemTemplate(item *menu.Item) string {
+	if name := ItemTemplateExtra.Get(item); name != "" {
+		return name
+	}
+	return o.LevelTemplates[item.Level()]
+}
+
+// SetLevelTemplates sets the value of the LevelTemplates field in the Options struct and returns the pointer
+// to the Options struct.
+func (o *Options) SetLevelTemplates(templates map[int]string) *Options {
+	o.LevelTemplates = maps.Clone(templates)
+	return o
+}
+
+// WithLevelTemplates is a function that returns an Option setting the templates rendering the items per level,
+// e.g. WithLevelTemplates(map[int]string{1: "@mega/column.html", 2: "@mega/link.html"}), see Options.ItemTemplate.
+func WithLevelTemplates(templates map[int]string) Option {
+	return func(options *Options) {
+		options.SetLevelTemplates(templates)
+	}
+}
@@ -0,0 +1,204 @@
+package renderer
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/gowool/menu"
+)
+
+var (
+	_ Renderer       = JSONRenderer{}
+	_ WriterRenderer = JSONRenderer{}
+)
+
+// JSONIndentExtra holds the indentation of the JSON documents rendered by JSONRenderer, compact when empty.
+var JSONIndentExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "json_indent"), "", "json_indent")
+
+// JSONMenu is the document rendered by JSONRenderer.
+type JSONMenu struct {
+	Name    string     `json:"name"`
+	Channel string     `json:"channel,omitempty"`
+	Items   []JSONItem `json:"items"`
+	// Truncated reports whether items were left out by the Budget option.
+	Truncated bool `json:"truncated,omitempty"`
+	// State is the navigation state of the menu, set by the EmbedState option.
+	State *MenuState `json:"state,omitempty"`
+}
+
+// JSONItem is a visible item of a JSONMenu, with the state computed by the renderer.
+type JSONItem struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	// Label is the label resolved in the context, as text unless HTML is true.
+	Label string `json:"label"`
+	// HTML reports whether Label is safe HTML, see the AllowSafeLabels option.
+	HTML bool `json:"html,omitempty"`
+	// URI is the URI filtered by the URI options, empty if the item is not a link.
+	URI      string `json:"uri,omitempty"`
+	Level    int    `json:"level"`
+	Current  bool   `json:"current"`
+	Ancestor bool   `json:"ancestor"`
+	First    bool   `json:"first"`
+	Last     bool   `json:"last"`
+	Branch   bool   `json:"branch"`
+	Open     bool   `json:"open,omitempty"`
+	// Classes holds the classes of the list element of the item, as computed by ListRenderer.
+	Classes         []string       `json:"classes,omitempty"`
+	Attributes      map[string]any `json:"attributes,omitempty"`
+	LinkAttributes  map[string]any `json:"link_attributes,omitempty"`
+	LabelAttributes map[string]any `json:"label_attributes,omitempty"`
+	Children        []JSONItem     `json:"children,omitempty"`
+}
+
+// JSONRenderer renders the visible items of a menu as a JSON document, see JSONMenu, for single page
+// applications and APIs rendering menus on the client side. The items are filtered and limited in depth
+// like ListRenderer does, and their current, ancestor and branch states and classes are computed with the matcher.
+//
+// Output example:
+//
+//	{"name":"main","items":[{"name":"blog","key":"blog","label":"Blog","uri":"/blog","level":1,"current":true,...}]}
+type JSONRenderer struct {
+	matcher menu.Matcher
+	options *Options
+}
+
+// NewJSONRenderer creates a new JSONRenderer with the given matcher and options.
+func NewJSONRenderer(matcher menu.Matcher, options ...Option) JSONRenderer {
+	return JSONRenderer{
+		matcher: matcher,
+		options: NewOptions(options...),
+	}
+}
+
+// Menu returns the document rendered for the item, e.g. to embed it in a larger API response.
+func (r JSONRenderer) Menu(ctx context.Context, item *menu.Item, options ...Option) (JSONMenu, error) {
+	ctx = menu.WithMatcher(ctx, r.matcher)
+	opts := r.options.Copy().Apply(options...)
+	item = opts.prepare(ctx, r.matcher, item)
+
+	var doc JSONMenu
+	_, err := run(ctx, "json", item, opts, func(ctx context.Context) (string, error) {
+		doc = r.menu(ctx, item, opts)
+		return "", nil
+	})
+
+	if opts.ClearMatcher {
+		r.matcher.Clear()
+	}
+
+	return doc, err
+}
+
+// Render renders the menu item and its visible descendants as a JSON document.
+func (r JSONRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	var b strings.Builder
+	if err := r.RenderTo(ctx, &b, item, options...); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// RenderTo renders the menu item like Render and writes the JSON document to w.
+func (r JSONRenderer) RenderTo(ctx context.Context, w io.Writer, item *menu.Item, options ...Option) error {
+	ctx = menu.WithMatcher(ctx, r.matcher)
+	opts := r.options.Copy().Apply(options...)
+	item = opts.prepare(ctx, r.matcher, item)
+
+	err := runTo(ctx, "json", w, item, opts, func(ctx context.Context, w writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", JSONIndentExtra.Get(opts))
+		return encoder.Encode(r.menu(ctx, item, opts))
+	})
+
+	if opts.ClearMatcher {
+		r.matcher.Clear()
+	}
+
+	return err
+}
+
+func (r JSONRenderer) menu(ctx context.Context, item *menu.Item, options *Options) JSONMenu {
+	doc := JSONMenu{
+		Name:      item.Name,
+		Channel:   options.Channel,
+		Truncated: options.Truncated(),
+	}
+	if options.EmbedState {
+		state := options.MenuState(ctx, r.matcher, item)
+		doc.State = &state
+	}
+	doc.Items = r.children(ctx, item, options)
+	return doc
+}
+
+// children returns the visible children of the item, or nil if the item is not a branch.
+func (r JSONRenderer) children(ctx context.Context, item *menu.Item, options *Options) []JSONItem {
+	if !options.IsBranch(ctx, item) {
+		return nil
+	}
+
+	options = options.SubDepth().SubMatchingDepth()
+
+	children := make([]JSONItem, 0, len(item.Children))
+	for _, child := range options.VisibleChildren(ctx, item) {
+		children = append(children, r.item(ctx, child, options.ForItem(child)))
+	}
+	return children
+}
+
+func (r JSONRenderer) item(ctx context.Context, item *menu.Item, options *Options) JSONItem {
+	defer annotatePanic(options, item)
+
+	result := JSONItem{
+		ID:              item.ID,
+		Name:            item.Name,
+		Key:             item.Key(),
+		URI:             options.URI(item.URI),
+		Level:           item.Level(),
+		Current:         r.matcher.IsCurrent(ctx, item),
+		First:           options.ActsLikeFirst(ctx, item),
+		Last:            options.ActsLikeLast(ctx, item),
+		Branch:          options.IsBranch(ctx, item),
+		Attributes:      item.Attributes,
+		LinkAttributes:  options.DropdownAttributes(ctx, item, options.LinkAttributes(ctx, item)),
+		LabelAttributes: item.LabelAttributes,
+	}
+	result.Ancestor = !result.Current && r.matcher.IsAncestor(ctx, item, options.MatchingDepth)
+	result.Open = result.Branch && options.IsOpen(ctx, item)
+
+	if options.AllowSafeLabels && menu.SafeLabelExtra.Get(item) {
+		result.Label, result.HTML = item.ResolveLabel(ctx, html.EscapeString), true
+	} else {
+		result.Label = item.ResolveLabel(ctx, nil)
+	}
+
+	classes := append(menu.ClassList(item.Attributes["class"]), options.ItemClasses(ctx, item)...)
+	switch {
+	case result.Current:
+		classes = append(classes, options.CurrentClass)
+	case result.Ancestor:
+		classes = append(classes, options.AncestorClass, options.AncestorDepthClass(ctx, r.matcher, item))
+	}
+	if result.First {
+		classes = append(classes, options.FirstClass)
+	}
+	if result.Last {
+		classes = append(classes, options.LastClass)
+	}
+	if result.Branch {
+		classes = append(classes, options.BranchClass, options.DropdownClass(ctx, item), options.OpenClassFor(ctx, item))
+	} else if options.IsStop() || !options.Expands(item) || !options.HasVisibleChildren(ctx, item) {
+		classes = append(classes, options.LeafClass)
+	}
+	result.Classes = menu.ClassList(classes)
+
+	result.Children = r.children(ctx, item, options)
+
+	return result
+}
@@ -0,0 +1,148 @@
+package renderer
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/gowool/menu"
+)
+
+var _ Renderer = JSONLDRenderer{}
+
+// JSONLDBaseURLExtra holds the base URL resolving the relative URIs of the items rendered by JSONLDRenderer,
+// e.g. "https://example.com". The scheme and host of the request URL carried by the context are used when empty,
+// see menu.WithRequestURL, and the URIs are kept as is when the context carries none.
+var JSONLDBaseURLExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "jsonld_base_url"), "", "jsonld_base_url")
+
+// JSONLDNavigation is the schema.org ItemList of SiteNavigationElement rendered by JSONLDRenderer.
+type JSONLDNavigation struct {
+	Context  string          `json:"@context"`
+	Type     string          `json:"@type"`
+	Name     string          `json:"name,omitempty"`
+	Elements []JSONLDElement `json:"itemListElement"`
+}
+
+// JSONLDElement is a schema.org SiteNavigationElement, the nested navigation elements being its parts.
+type JSONLDElement struct {
+	Type     string          `json:"@type"`
+	Position int             `json:"position"`
+	Name     string          `json:"name"`
+	URL      string          `json:"url"`
+	HasPart  []JSONLDElement `json:"hasPart,omitempty"`
+}
+
+// JSONLDRenderer renders the schema.org SiteNavigationElement JSON-LD of a menu, helping search engines
+// understand the structure of the site, as a <script type="application/ld+json"> element meant for the
+// <head> of the pages. Only the top level items are rendered by default, the Depth option renders deeper
+// levels as the parts of their parent. Like SitemapRenderer, the hidden items, the items hidden in the Channel
+// and the items rejected by the Filters option are skipped. The items without URI are not navigation elements,
+// their children are rendered in their place.
+//
+// Output example:
+//
+//	<script type="application/ld+json">{"@context":"https://schema.org","@type":"ItemList","itemListElement":[
+//	{"@type":"SiteNavigationElement","position":1,"name":"Blog","url":"https://example.com/blog"}]}</script>
+type JSONLDRenderer struct {
+	options *Options
+}
+
+// NewJSONLDRenderer creates a new JSONLDRenderer with the given options. The Depth option defaults to 1.
+func NewJSONLDRenderer(options ...Option) JSONLDRenderer {
+	depth := 1
+	return JSONLDRenderer{
+		options: NewOptions(append([]Option{WithDepth(&depth)}, options...)...),
+	}
+}
+
+// Navigation returns the document rendered for the item, e.g. to embed it in a larger JSON-LD graph.
+func (r JSONLDRenderer) Navigation(ctx context.Context, item *menu.Item, options ...Option) (JSONLDNavigation, error) {
+	opts := r.options.Copy().Apply(options...)
+
+	var doc JSONLDNavigation
+	_, err := run(ctx, "jsonld", item, opts, func(ctx context.Context) (string, error) {
+		doc = r.navigation(ctx, item, opts)
+		return "", nil
+	})
+	return doc, err
+}
+
+// Render renders the JSON-LD of the item in a <script type="application/ld+json"> element.
+func (r JSONLDRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	opts := r.options.Copy().Apply(options...)
+
+	return run(ctx, "jsonld", item, opts, func(ctx context.Context) (string, error) {
+		// json.Marshal escapes <, > and &, so the document cannot close the script element
+		data, err := json.Marshal(r.navigation(ctx, item, opts))
+		if err != nil {
+			return "", err
+		}
+		return `<script type="application/ld+json">` + string(data) + `</script>`, nil
+	})
+}
+
+func (r JSONLDRenderer) navigation(ctx context.Context, item *menu.Item, options *Options) JSONLDNavigation {
+	return JSONLDNavigation{
+		Context:  "https://schema.org",
+		Type:     "ItemList",
+		Name:     item.ResolveLabel(ctx, nil),
+		Elements: r.elements(ctx, item, r.base(ctx, options), options),
+	}
+}
+
+// base returns the URL resolving the relative URIs of the items, or nil.
+func (r JSONLDRenderer) base(ctx context.Context, options *Options) *url.URL {
+	if base := JSONLDBaseURLExtra.Get(options); base != "" {
+		if u, err := url.Parse(base); err == nil {
+			return u
+		}
+	}
+	if u, ok := menu.RequestURL(ctx); ok && u.Host != "" {
+		return &url.URL{Scheme: u.Scheme, Host: u.Host}
+	}
+	return nil
+}
+
+// elements returns the navigation elements of the visible children of the item, numbered from 1.
+func (r JSONLDRenderer) elements(ctx context.Context, item *menu.Item, base *url.URL, options *Options) []JSONLDElement {
+	if options.IsStop() || !item.DisplayChildren {
+		return nil
+	}
+
+	// the children of the items without URI are lifted to the level of their parent
+	parent := options.Copy()
+	options = options.SubDepth()
+
+	elements := []JSONLDElement{}
+	for _, child := range options.VisibleChildren(ctx, item) {
+		uri := options.URI(child.URI)
+		if uri == "" {
+			elements = append(elements, r.elements(ctx, child, base, parent.Copy())...)
+			continue
+		}
+
+		elements = append(elements, JSONLDElement{
+			Type:    "SiteNavigationElement",
+			Name:    child.ResolveLabel(ctx, nil),
+			URL:     r.resolve(base, uri),
+			HasPart: r.elements(ctx, child, base, options.Copy()),
+		})
+	}
+
+	for i := range elements {
+		elements[i].Position = i + 1
+	}
+	return elements
+}
+
+// resolve returns the URI resolved against the base URL, or the URI itself if it cannot be resolved.
+func (r JSONLDRenderer) resolve(base *url.URL, uri string) string {
+	if base == nil {
+		return uri
+	}
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,164 @@
+package renderer
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"unicode"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer/htmlutil"
+)
+
+// Default values of the SkipLink fields.
+const (
+	DefaultSkipLinkTarget = "content"
+	DefaultSkipLinkLabel  = "Skip to content"
+	DefaultSkipLinkClass  = "skip-link"
+)
+
+// SkipLink describes the "Skip to content" link rendered before a menu, letting keyboard and screen reader users
+// jump over the navigation to the main content of the page. It is configured with the SkipLink option and rendered
+// by ListRenderer and by the "@menu/skip-link.html" template of the built-in themes.
+//
+// The link is usually visually hidden until it is focused, e.g. with Bootstrap's visually-hidden-focusable class.
+type SkipLink struct {
+	// Target is the id of the main content of the page, DefaultSkipLinkTarget when empty.
+	Target string `json:"target,omitempty"`
+	// Label is the text of the link, DefaultSkipLinkLabel when empty.
+	Label string `json:"label,omitempty"`
+	// Class is the class of the link, DefaultSkipLinkClass when empty.
+	Class string `json:"class,omitempty"`
+}
+
+// Href returns the target of the link, the id of the main content prefixed with "#".
+func (s *SkipLink) Href() string {
+	if s.Target == "" {
+		return "#" + DefaultSkipLinkTarget
+	}
+	return "#" + strings.TrimPrefix(s.Target, "#")
+}
+
+// Text returns the text of the link.
+func (s *SkipLink) Text() string {
+	if s.Label == "" {
+		return DefaultSkipLinkLabel
+	}
+	return s.Label
+}
+
+// LinkClass returns the class of the link.
+func (s *SkipLink) LinkClass() string {
+	if s.Class == "" {
+		return DefaultSkipLinkClass
+	}
+	return s.Class
+}
+
+// html renders the skip link.
+func (s *SkipLink) html() string {
+	return fmt.Sprintf(`<a class="%s" href="%s">%s</a>`,
+		htmlutil.EncodeHTML(s.LinkClass()),
+		htmlutil.EncodeAttribute("href", s.Href()),
+		htmlutil.EncodeHTML(s.Text()),
+	)
+}
+
+// LandmarkID returns the id given to the element of the item by the Landmarks option, or an empty string.
+// The list of the rendered root gets the id "menu-<name>", e.g. "menu-main", and the list elements of the top
+// level items get the id of the root list followed by their own name, e.g. "menu-main-blog", so that skip links
+// and in-page anchors can target every top level menu. Names are lowercased and their other characters than
+// letters and digits replaced by "-". Elements which already have an id keep it.
+func (o *Options) LandmarkID(item *menu.Item) string {
+	if !o.Landmarks || o.root == nil || item == nil {
+		return ""
+	}
+
+	switch {
+	case item == o.root:
+		if _, ok := item.ChildrenAttributes["id"]; ok {
+			return ""
+		}
+		return landmarkID(o.root)
+	case item.Parent == o.root:
+		if _, ok := item.Attributes["id"]; ok {
+			return ""
+		}
+		return landmarkID(o.root) + "-" + landmarkSlug(item.Name)
+	}
+	return ""
+}
+
+// LandmarkAttributes returns the attributes of the element of the item completed with its id when the Landmarks
+// option gives it one, see LandmarkID: the attributes of the list of the root, or of the list element of a top
+// level item.
+func (o *Options) LandmarkAttributes(item *menu.Item, attributes map[string]any) map[string]any {
+	id := o.LandmarkID(item)
+	if id == "" {
+		return attributes
+	}
+
+	attributes = maps.Clone(attributes)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	attributes["id"] = id
+	return attributes
+}
+
+// landmarkID returns the id of the list of the root, its own id if it has one.
+func landmarkID(root *menu.Item) string {
+	if id, ok := root.ChildrenAttributes["id"].(string); ok && id != "" {
+		return id
+	}
+	if slug := landmarkSlug(root.Name); slug != "" {
+		return "menu-" + slug
+	}
+	return "menu"
+}
+
+// landmarkSlug lowercases the name and replaces its runs of other characters than letters and digits by "-".
+func landmarkSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// SetSkipLink sets the value of the SkipLink field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetSkipLink(skipLink *SkipLink) *Options {
+	o.SkipLink = skipLink
+	return o
+}
+
+// SetLandmarks sets the value of the Landmarks field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetLandmarks(landmarks bool) *Options {
+	o.Landmarks = landmarks
+	return o
+}
+
+// WithSkipLink is a function that returns an Option for rendering a "Skip to content" link before the menu,
+// e.g. WithSkipLink(&SkipLink{Target: "main"}). A nil skip link renders none.
+func WithSkipLink(skipLink *SkipLink) Option {
+	return func(options *Options) {
+		options.SetSkipLink(skipLink)
+	}
+}
+
+// WithLandmarks is a function that returns an Option for giving an id to the list of the menu and to the list
+// elements of its top level items, see Options.LandmarkID.
+func WithLandmarks(landmarks bool) Option {
+	return func(options *Options) {
+		options.SetLandmarks(landmarks)
+	}
+}
@@ -0,0 +1,77 @@
+package renderer
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/gowool/menu"
+)
+
+// ForLevel returns a copy of the options for rendering an item of the level, the top level items being of level 1,
+// see menu.Item.Level, with the LevelOptions of the level applied, e.g. other branch and leaf classes for the items
+// of a dropdown.
+// The overrides of a level do not cascade: the items of the levels below get the options without them,
+// with their own overrides applied, if any. The Depth and MatchingDepth options carry on counting down.
+func (o *Options) ForLevel(level int) *Options {
+	base := o.Copy()
+	if o.unleveled != nil {
+		depth, matchingDepth := base.Depth, base.MatchingDepth
+		base = o.unleveled.Copy()
+		base.Depth, base.MatchingDepth = depth, matchingDepth
+	}
+
+	overrides := o.LevelOptions[level]
+	if len(overrides) == 0 {
+		return base
+	}
+
+	leveled := base.Copy().Apply(overrides...)
+	leveled.unleveled = base
+	return leveled
+}
+
+// ForItem returns a copy of the options for rendering the item, the options of its level, see ForLevel.
+// The "more" branch of the MaxItems option does not count against the Depth and MatchingDepth options,
+// so its items are rendered whenever the top level items are.
+func (o *Options) ForItem(item *menu.Item) *Options {
+	return o.ForLevel(item.Level()).skipMore(item)
+}
+
+// skipMore adds a level to the Depth and MatchingDepth options when the item is the "more" branch
+// of the MaxItems option, so the levels below the branch are counted as if it was not there.
+func (o *Options) skipMore(item *menu.Item) *Options {
+	if o.more == nil || item != o.more {
+		return o
+	}
+	if o.Depth != nil {
+		*o.Depth++
+	}
+	if o.MatchingDepth != nil {
+		*o.MatchingDepth++
+	}
+	return o
+}
+
+// SetLevelOptions sets the value of the LevelOptions field in the Options struct and returns the pointer
+// to the Options struct.
+func (o *Options) SetLevelOptions(options map[int][]Option) *Options {
+	o.LevelOptions = maps.Clone(options)
+	for level, overrides := range o.LevelOptions {
+		o.LevelOptions[level] = slices.Clone(overrides)
+	}
+	return o
+}
+
+// WithLevelOptions is a function that returns an Option overriding the options per level, see Options.ForLevel.
+// The options of a level apply to its items, their links and labels, and to the lists of their children.
+//
+// Example usage, for a navbar whose dropdowns use other classes:
+//
+//	WithLevelOptions(map[int][]Option{
+//		2: {WithBranchClass("dropdown-submenu"), WithLeafClass("dropdown-leaf")},
+//	})
+func WithLevelOptions(options map[int][]Option) Option {
+	return func(o *Options) {
+		o.SetLevelOptions(options)
+	}
+}
@@ -3,15 +3,18 @@ package renderer
 import (
 	"context"
 	"fmt"
-	"html"
+	"io"
 	"maps"
 	"strings"
 
 	"github.com/gowool/menu"
-	"github.com/gowool/menu/internal"
+	"github.com/gowool/menu/renderer/htmlutil"
 )
 
-var _ Renderer = ListRenderer{}
+var (
+	_ Renderer       = ListRenderer{}
+	_ WriterRenderer = ListRenderer{}
+)
 
 // ListRenderer is a type that implements the Renderer interface and is responsible for rendering menus in list format.
 // Render method of the ListRenderer type is used to render the menu and return the generated HTML string.
@@ -51,157 +54,214 @@ func NewListRenderer(matcher menu.Matcher, options ...Option) ListRenderer {
 	}
 }
 
-// Render renders the menu item and its children into a HTML list.
+// NewOrderedListRenderer creates a new ListRenderer rendering ordered lists, see WithListElement.
+func NewOrderedListRenderer(matcher menu.Matcher, options ...Option) ListRenderer {
+	return NewListRenderer(matcher, append([]Option{WithListElement("ol")}, options...)...)
+}
+
+// Render renders the menu item and its children into a HTML list, preceded by the Brand option if set
+// and wrapped in the Nav option if set.
 // It accepts a context, the menu item to render, and optional rendering options.
 // It returns the rendered content as a string and an error if any.
+// An error only occurs when a recovered panic is returned because of the Recover option.
 func (r ListRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	ctx = menu.WithMatcher(ctx, r.matcher)
 	opts := r.options.Copy().Apply(options...)
+	item = opts.prepare(ctx, r.matcher, item)
 
-	content := r.renderList(ctx, item, item.ChildrenAttributes, opts)
+	content, err := run(ctx, "list", item, opts, func(ctx context.Context) (string, error) {
+		var b strings.Builder
+		r.render(ctx, &b, item, opts)
+		return b.String(), nil
+	})
 
 	if opts.ClearMatcher {
 		r.matcher.Clear()
 	}
 
-	return content, nil
+	return content, err
+}
+
+// RenderTo renders the menu item like Render, but writes the HTML to w as it is generated instead of returning it.
+// The returned error also reports the failure to write to w.
+func (r ListRenderer) RenderTo(ctx context.Context, w io.Writer, item *menu.Item, options ...Option) error {
+	ctx = menu.WithMatcher(ctx, r.matcher)
+	opts := r.options.Copy().Apply(options...)
+	item = opts.prepare(ctx, r.matcher, item)
+
+	err := runTo(ctx, "list", w, item, opts, func(ctx context.Context, w writer) error {
+		r.render(ctx, w, item, opts)
+		return nil
+	})
+
+	if opts.ClearMatcher {
+		r.matcher.Clear()
+	}
+
+	return err
+}
+
+// render writes the SkipLink and Brand options, if set, followed by the list of the children of the item
+// and the truncation marker of the Budget option, all but the skip link wrapped in the Nav option if set.
+func (r ListRenderer) render(ctx context.Context, w io.StringWriter, item *menu.Item, options *Options) {
+	if options.SkipLink != nil {
+		_, _ = w.WriteString(r.format(options.SkipLink.html(), "skip-link", 0, options))
+	}
+	if options.Nav != nil {
+		_, _ = w.WriteString(r.format(fmt.Sprintf("<nav%s>", htmlutil.Attributes(options.NavAttributes(ctx, item))), "nav", 0, options))
+	}
+	if options.Brand != nil {
+		_, _ = w.WriteString(r.format(options.Brand.html(options), "brand", 0, options))
+	}
+	r.renderList(ctx, w, item, options.MenuStateAttributes(ctx, r.matcher, item, options.LandmarkAttributes(item, item.ChildrenAttributes)), options)
+	if marker := options.BudgetMarker(); marker != "" {
+		_, _ = w.WriteString(r.format(marker, "marker", 0, options))
+	}
+	if options.Nav != nil {
+		_, _ = w.WriteString(r.format("</nav>", "nav", 0, options))
+	}
 }
 
 // renderList renders a list of items and their children in HTML format.
 //
 // If the options indicate that the rendering should stop or if the item
-// has no children or is not set to display its children, an empty string
-// is returned.
+// has no children or is not set to display its children, nothing is written.
 //
-// The method constructs an HTML string by appending the formatted list
-// opening tag, the rendered children, and the formatted list closing tag.
+// The method writes the formatted list opening tag, the rendered children,
+// and the formatted list closing tag to w.
 //
 // The rendered children are obtained by calling the renderChildren method
 // and passing it the parent item, a context, and the options.
 //
 // The method then constructs the opening and closing tags by calling the
 // format method, passing in the appropriate arguments.
-//
-// Finally, the method returns the resulting HTML string.
-func (r ListRenderer) renderList(ctx context.Context, item *menu.Item, attributes map[string]any, options *Options) string {
-	if options.IsStop() || !item.HasChildren() || !item.DisplayChildren {
-		return ""
+func (r ListRenderer) renderList(ctx context.Context, w io.StringWriter, item *menu.Item, attributes map[string]any, options *Options) {
+	if options.IsStop() || !options.Expands(item) || !options.HasVisibleChildren(ctx, item) || !item.DisplayChildren {
+		return
 	}
 
 	level := item.Level()
 
-	var b strings.Builder
-	b.WriteString(r.format(fmt.Sprintf("<ul%s>", internal.HTMLAttributes(attributes)), "ul", level, options))
-	b.WriteString(r.renderChildren(ctx, item, options))
-	b.WriteString(r.format("</ul>", "ul", level, options))
+	element := options.ListElement()
 
-	return b.String()
+	_, _ = w.WriteString(r.format(fmt.Sprintf("<%s%s>", element, htmlutil.Attributes(attributes)), "ul", level, options))
+	r.renderChildren(ctx, w, item, options)
+	_, _ = w.WriteString(r.format(fmt.Sprintf("</%s>", element), "ul", level, options))
 }
 
 // renderChildren renders the children of a menu item with the given context and options.
-func (r ListRenderer) renderChildren(ctx context.Context, item *menu.Item, options *Options) string {
+func (r ListRenderer) renderChildren(ctx context.Context, w io.StringWriter, item *menu.Item, options *Options) {
 	options = options.SubDepth().SubMatchingDepth()
 
-	var b strings.Builder
-	for _, child := range item.Children {
-		b.WriteString(r.renderItem(ctx, child, options.Copy()))
+	for _, child := range options.Children(ctx, item) {
+		r.renderItem(ctx, w, child, options.ForItem(child))
 	}
-	return b.String()
 }
 
 // renderItem takes a context, an item, and options, and renders the item as an HTML list item.
-// If the item should not be displayed, it returns an empty string.
+// If the item should not be displayed, nothing is written.
 // It retrieves the item's classes and appends additional classes based on its properties and context.
 // The method then constructs the attributes, including the classes, for the <li> element.
-// It constructs a string builder and appends the opening <li> tag, followed by the rendered link for the item.
+// It writes the opening <li> tag, followed by the rendered link for the item.
 // If the item has children and should be displayed, it appends the appropriate classes for a branch element.
 // Otherwise, it appends the appropriate classes for a leaf element.
-// It then constructs the attributes for the children list, and appends the rendered list to the string builder.
-// Finally, it appends the closing </li> tag and returns the constructed string.
-func (r ListRenderer) renderItem(ctx context.Context, item *menu.Item, options *Options) string {
-	if !item.Display {
-		return ""
+// It then constructs the attributes for the children list, and writes the rendered list.
+// Finally, it writes the closing </li> tag.
+// When the ItemWrapper option is set, the list item is rendered into a buffer and written wrapped.
+func (r ListRenderer) renderItem(ctx context.Context, w io.StringWriter, item *menu.Item, options *Options) {
+	if !options.IsVisible(ctx, item) {
+		return
 	}
 
-	classes := make([]string, 0, 5)
-	classes = append(classes, item.Attribute("class", "").(string))
+	defer annotatePanic(options, item)
+
+	if options.ItemWrapper != nil {
+		var b strings.Builder
+		r.writeItem(ctx, &b, item, options)
+		_, _ = w.WriteString(options.ItemWrapper(ctx, item, b.String()))
+		return
+	}
+	r.writeItem(ctx, w, item, options)
+}
+
+// writeItem writes the list item of a visible item, see renderItem.
+func (r ListRenderer) writeItem(ctx context.Context, w io.StringWriter, item *menu.Item, options *Options) {
+	classes := menu.ClassList(item.Attributes["class"])
+	classes = append(classes, options.ItemClasses(ctx, item)...)
 
 	if r.matcher.IsCurrent(ctx, item) {
 		classes = append(classes, options.CurrentClass)
 	} else if r.matcher.IsAncestor(ctx, item, options.MatchingDepth) {
-		classes = append(classes, options.AncestorClass)
+		classes = append(classes, options.AncestorClass, options.AncestorDepthClass(ctx, r.matcher, item))
 	}
 
-	if item.ActsLikeFirst() {
+	if options.ActsLikeFirst(ctx, item) {
 		classes = append(classes, options.FirstClass)
 	}
-	if item.ActsLikeLast() {
+	if options.ActsLikeLast(ctx, item) {
 		classes = append(classes, options.LastClass)
 	}
 
-	if !options.IsStop() && item.HasChildren() {
+	if !options.IsStop() && options.Expands(item) && options.HasVisibleChildren(ctx, item) {
 		if item.DisplayChildren {
-			classes = append(classes, options.BranchClass)
+			classes = append(classes, options.BranchClass, options.DropdownClass(ctx, item), options.OpenClassFor(ctx, item))
 		}
 	} else {
 		classes = append(classes, options.LeafClass)
 	}
 
-	attributes := maps.Clone(item.Attributes)
-	attributes["class"] = internal.HTMLClasses(classes)
+	attributes := options.LandmarkAttributes(item, options.StateAttributes(item, maps.Clone(item.Attributes)))
+	attributes["class"] = htmlutil.Classes(classes)
 
 	level := item.Level()
 
-	var b strings.Builder
-	b.WriteString(r.format(fmt.Sprintf("<li%s>", internal.HTMLAttributes(attributes)), "li", level, options))
-	b.WriteString(r.renderLink(ctx, item, options))
+	_, _ = w.WriteString(r.format(fmt.Sprintf("<li%s>", htmlutil.Attributes(attributes)), "li", level, options))
+	_, _ = w.WriteString(r.renderLink(ctx, item, options))
 
-	classes = []string{
-		item.ChildrenAttribute("class", "").(string),
-		fmt.Sprintf("menu-level-%d", item.Level()),
-	}
+	classes = append(menu.ClassList(item.ChildrenAttributes["class"]), fmt.Sprintf("menu-level-%d", item.Level()))
 	attributes = maps.Clone(item.ChildrenAttributes)
-	attributes["class"] = internal.HTMLClasses(classes)
+	attributes["class"] = htmlutil.Classes(classes)
 
-	b.WriteString(r.renderList(ctx, item, attributes, options))
-	b.WriteString(r.format("</li>", "li", level, options))
-
-	return b.String()
+	r.renderList(ctx, w, item, attributes, options)
+	_, _ = w.WriteString(r.format("</li>", "li", level, options))
 }
 
 // renderLink renders a link element or a span element based on the item and options.
 // It returns the formatted link or span element.
 func (r ListRenderer) renderLink(ctx context.Context, item *menu.Item, options *Options) string {
 	var text string
-	if item.URI != "" && (!r.matcher.IsCurrent(ctx, item) || options.CurrentAsLink) {
-		text = r.renderLinkElement(item, options)
+	if options.URI(item.URI) != "" && (!r.matcher.IsCurrent(ctx, item) || options.CurrentAsLink) {
+		text = r.renderLinkElement(ctx, item, options.DropdownAttributes(ctx, item, options.LinkAttributes(ctx, item)), options)
 	} else {
-		text = r.renderSpanElement(item, options)
+		text = r.renderSpanElement(ctx, item, options.DropdownAttributes(ctx, item, item.LabelAttributes), options)
 	}
 	return r.format(text, "link", item.Level(), options)
 }
 
 // renderLinkElement formats a link element for a menu item.
-// It escapes the URI, applies link attributes and renders the label.
-func (r ListRenderer) renderLinkElement(item *menu.Item, options *Options) string {
-	return fmt.Sprintf(`<a href="%s"%s>%s</a>`, html.EscapeString(item.URI), internal.HTMLAttributes(item.LinkAttributes), r.renderLabel(item, options))
+// It encodes the URI with the href encoder, applies the link attributes and renders the label.
+func (r ListRenderer) renderLinkElement(ctx context.Context, item *menu.Item, attributes map[string]any, options *Options) string {
+	return fmt.Sprintf(`<a href="%s"%s>%s</a>`, htmlutil.EncodeAttribute("href", options.URI(item.URI)), htmlutil.Attributes(attributes), r.renderLabel(ctx, item, options))
 }
 
 // renderSpanElement renders a span element with the label of the menu item.
-// It formats the element using the internal.HTMLAttributes function to handle HTML attributes,
+// It formats the element using the htmlutil.Attributes function to handle HTML attributes,
 // and calls the renderLabel method to render the label itself. The resulting HTML element is returned as a string.
-// The function accepts the menu item and the options as parameters.
-func (r ListRenderer) renderSpanElement(item *menu.Item, options *Options) string {
-	return fmt.Sprintf("<span%s>%s</span>", internal.HTMLAttributes(item.LabelAttributes), r.renderLabel(item, options))
+// The function accepts the menu item, the label attributes and the options as parameters.
+func (r ListRenderer) renderSpanElement(ctx context.Context, item *menu.Item, attributes map[string]any, options *Options) string {
+	return fmt.Sprintf("<span%s>%s</span>", htmlutil.Attributes(attributes), r.renderLabel(ctx, item, options))
 }
 
 // renderLabel renders the label of a menu item.
 //
-// This method takes an item and options as input and returns the rendered label
+// This method takes a context, an item and options as input and returns the rendered label
 // as a string. The rendered label is the menu item's label with HTML special
 // characters escaped, unless the "AllowSafeLabels" option is set to true and the
-// item has the "safe_label" extra attribute set to true.
+// item has the "menu.safe_label" extra attribute set to true. Labels marked with the
+// "menu.interpolate" extra have their variables resolved from the context.
 //
 // Parameters:
+//   - ctx: The context carrying the variables of the label.
 //   - item: The menu item whose label should be rendered.
 //   - options: The options to be used during rendering.
 //
@@ -213,20 +273,17 @@ func (r ListRenderer) renderSpanElement(item *menu.Item, options *Options) strin
 //
 //	renderer := ListRenderer{}
 //	options := &Options{AllowSafeLabels: true}
-//	label := renderer.renderLabel(item, options)
-func (r ListRenderer) renderLabel(item *menu.Item, options *Options) string {
-	if options.AllowSafeLabels && item.Extra("safe_label", false).(bool) {
-		return item.Label
-	}
-	return html.EscapeString(item.Label)
+//	label := renderer.renderLabel(ctx, item, options)
+func (r ListRenderer) renderLabel(ctx context.Context, item *menu.Item, options *Options) string {
+	return label(ctx, item, options) + string(options.Badge(item))
 }
 
 // format formats the given content based on the type and level parameters, as well as the options provided.
-// If the "compressed" extra option is set to true, the content is returned as is. Otherwise, the content is indented
-// according to the level parameter and returned with a newline character appended at the end.
-// The type parameter determines the indentation spacing as follows:
-// - "ul" or "link": level * 4 spaces
-// - "li": level * 4 - 2 spaces
+// If the Compressed option, or the deprecated "compressed" extra option, is set to true, the content is returned as is.
+// Otherwise, the content is indented according to the level parameter and returned with a newline character appended
+// at the end. The type parameter determines the indentation spacing, in IndentWidth characters, as follows:
+// - "ul" or "link": level * 2 widths, i.e. level * 4 spaces by default
+// - "li": level * 2 - 1 widths, i.e. level * 4 - 2 spaces by default
 // Parameters:
 //   - content: the content to be formatted
 //   - typ: the type of content
@@ -236,17 +293,25 @@ func (r ListRenderer) renderLabel(item *menu.Item, options *Options) string {
 // Returns:
 //   - the formatted content
 func (r ListRenderer) format(content, typ string, level int, options *Options) string {
-	if options.Extra("compressed", false).(bool) {
+	if options.Compressed || CompressedExtra.Get(options) {
 		return content
 	}
 
+	width, char := options.IndentWidth, options.IndentChar
+	if width <= 0 {
+		width = 2
+	}
+	if char == "" {
+		char = " "
+	}
+
 	spacing := 0
 	switch typ {
 	case "ul", "link":
-		spacing = level * 4
+		spacing = level * 2 * width
 	case "li":
-		spacing = level*4 - 2
+		spacing = (level*2 - 1) * width
 	}
 
-	return strings.Repeat(" ", spacing) + content + "\n"
+	return strings.Repeat(char, max(spacing, 0)) + content + "\n"
 }
@@ -0,0 +1,43 @@
+package renderer
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gowool/menu"
+)
+
+func TestListRendererSeveralCurrentItems(t *testing.T) {
+	root, err := menu.NewItem("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blog, _ := root.AddChild("blog", menu.WithURI("/blog"))
+	_, _ = blog.AddChild("post", menu.WithURI("/post"))
+	docs, _ := root.AddChild("docs", menu.WithURI("/docs"))
+	_, _ = docs.AddChild("post", menu.WithURI("/post"))
+	_, _ = root.AddChild("about", menu.WithURI("/about"))
+
+	ctx := menu.WithRequestURL(context.Background(), &url.URL{Path: "/post"})
+	matcher := menu.NewCoreMatcher(menu.URLVoter{})
+
+	html, err := NewListRenderer(matcher, WithCompressed(true)).Render(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`<li class="current-ancestor first"><a href="/blog"></a><ul class="menu-level-1"><li class="current first last"><a href="/post">`,
+		`<li class="current-ancestor"><a href="/docs"></a><ul class="menu-level-1"><li class="current first last"><a href="/post">`,
+		`<li class="last"><a href="/about"></a></li>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Render() = %s, want it to contain %s", html, want)
+		}
+	}
+	if n := strings.Count(html, `class="current `); n != 2 {
+		t.Errorf("Render() marks %d items as current, want 2", n)
+	}
+}
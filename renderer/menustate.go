@@ -0,0 +1,106 @@
+package renderer
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	"github.com/gowool/menu"
+)
+
+// MenuState is the navigation state of a rendered menu embedded for client side routers by the EmbedState option:
+// in the data-menu-state attribute of the list of the root by ListRenderer and the templates, as JSON, and in the
+// State field of JSONMenu. On a soft navigation, a router looks the new path up in Links and moves the current
+// and ancestor classes to the list elements with the matching data-menu-key attributes, see the MenuKeys option,
+// without fetching the menu again.
+type MenuState struct {
+	// Path is the path of the request URL carried by the context, see menu.WithRequestURL.
+	Path string `json:"path,omitempty"`
+	// Current holds the keys of the current items, see menu.Item.Key.
+	Current []string `json:"current"`
+	// Ancestors holds the keys of the ancestors of the current items, the top level ones first.
+	Ancestors []string `json:"ancestors"`
+	// IDs holds the IDs of the current items that have one.
+	IDs []string `json:"ids,omitempty"`
+	// Links maps the URIs of the rendered items to their keys, the first item winning for a URI.
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// MenuState returns the navigation state of the rendered items of the menu, see MenuState.
+// Only the visible items, up to the Depth option, are part of the state.
+func (o *Options) MenuState(ctx context.Context, matcher menu.Matcher, root *menu.Item) MenuState {
+	state := MenuState{
+		Current:   []string{},
+		Ancestors: []string{},
+		Links:     map[string]string{},
+	}
+	if u, ok := menu.RequestURL(ctx); ok {
+		state.Path = u.Path
+	}
+
+	var walk func(item *menu.Item, options *Options)
+	walk = func(item *menu.Item, options *Options) {
+		if options.IsStop() || !item.DisplayChildren {
+			return
+		}
+		options = options.SubDepth()
+
+		for _, child := range options.VisibleChildren(ctx, item) {
+			key := child.Key()
+			if uri := options.URI(child.URI); uri != "" && uri != UnsafeURI {
+				if _, ok := state.Links[uri]; !ok {
+					state.Links[uri] = key
+				}
+			}
+
+			switch {
+			case matcher.IsCurrent(ctx, child):
+				state.Current = append(state.Current, key)
+				if child.ID != "" {
+					state.IDs = append(state.IDs, child.ID)
+				}
+			case matcher.IsAncestor(ctx, child, nil):
+				state.Ancestors = append(state.Ancestors, key)
+			}
+
+			walk(child, options.Copy().skipMore(child))
+		}
+	}
+	walk(root, o.Copy())
+
+	return state
+}
+
+// MenuStateAttributes returns the attributes of the list of the root completed with its data-menu-state attribute
+// when the EmbedState option is set, see MenuState. The attributes of the other items are returned unchanged.
+func (o *Options) MenuStateAttributes(ctx context.Context, matcher menu.Matcher, item *menu.Item, attributes map[string]any) map[string]any {
+	if !o.EmbedState || item != o.root || matcher == nil {
+		return attributes
+	}
+
+	data, err := json.Marshal(o.MenuState(ctx, matcher, item))
+	if err != nil {
+		return attributes
+	}
+
+	attributes = maps.Clone(attributes)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	attributes["data-menu-state"] = string(data)
+	return attributes
+}
+
+// SetEmbedState sets the value of the EmbedState field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetEmbedState(embed bool) *Options {
+	o.EmbedState = embed
+	return o
+}
+
+// WithEmbedState is a function that returns an Option for embedding the navigation state of the menu for client
+// side routers, see MenuState. Combine it with WithMenuKeys so the routers can find the list elements of the items.
+func WithEmbedState(embed bool) Option {
+	return func(options *Options) {
+		options.SetEmbedState(embed)
+	}
+}
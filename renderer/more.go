@@ -0,0 +1,157 @@
+package renderer
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/gowool/menu"
+)
+
+// DefaultMoreLabel is the label of the branch collecting the overflow of the MaxItems option.
+const DefaultMoreLabel = "More"
+
+// moreCacheSize is the number of rendered items whose "more" branch is kept by a moreCache.
+const moreCacheSize = 64
+
+// moreCache keeps the "more" branch of the MaxItems option of the rendered items across renders, so the same
+// overflow yields the same items, e.g. for the caches of the matchers keyed by item. The overflow is compared
+// by content, see menu.Item.Hash, so the items edited in place are copied again.
+type moreCache struct {
+	mu    sync.Mutex
+	items map[*menu.Item]moreEntry
+}
+
+type moreEntry struct {
+	label    string
+	overflow []*menu.Item
+	hashes   []string
+	more     *menu.Item
+}
+
+// get returns the "more" branch of the root holding copies of the overflow items, built on first use.
+func (c *moreCache) get(root *menu.Item, label string, overflow []*menu.Item) *menu.Item {
+	if c == nil {
+		return newMore(root, label, overflow)
+	}
+
+	hashes := make([]string, len(overflow))
+	for i, item := range overflow {
+		hashes[i] = item.Hash()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.items[root]; ok && entry.label == label && slices.Equal(entry.overflow, overflow) &&
+		slices.Equal(entry.hashes, hashes) {
+		return entry.more
+	}
+
+	more := newMore(root, label, overflow)
+	if more == nil {
+		return nil
+	}
+	if c.items == nil || len(c.items) >= moreCacheSize {
+		c.items = map[*menu.Item]moreEntry{}
+	}
+	c.items[root] = moreEntry{label: label, overflow: overflow, hashes: hashes, more: more}
+	return more
+}
+
+// newMore creates the "more" branch of the root holding copies of the overflow items, so the items
+// of the branch are one level below it, like the items of the other branches, and the tree is unchanged.
+func newMore(root *menu.Item, label string, overflow []*menu.Item) *menu.Item {
+	more, err := menu.NewItem("more", menu.WithLabel(label))
+	if err != nil {
+		return nil
+	}
+	for _, item := range overflow {
+		child, err := item.Copy()
+		if err != nil {
+			return nil
+		}
+		if _, err = more.AddChild(child); err != nil {
+			return nil
+		}
+	}
+	more.Parent = root
+	return more
+}
+
+// prepareMore collapses the visible children of the rendered item beyond the MaxItems option into a trailing
+// "more" branch labeled with the MoreLabel option, e.g. for a navbar with a bounded number of entries.
+// The branch is not part of the tree: it is only returned by Options.Children, and holds copies of the overflow
+// items, so their levels, paths and ancestors are the ones of the items of a branch, and the matcher marks it
+// as an ancestor when one of them is current. The branch of an item is reused across the renders
+// of the same options as long as its overflow is unchanged.
+func (o *Options) prepareMore(ctx context.Context, root *menu.Item) {
+	o.more = nil
+	if o.MaxItems <= 0 {
+		return
+	}
+
+	children := o.Children(ctx, root)
+
+	var visible int
+	for _, child := range children {
+		if o.IsVisible(ctx, child) {
+			visible++
+		}
+	}
+	if visible <= o.MaxItems {
+		return
+	}
+
+	label := o.MoreLabel
+	if label == "" {
+		label = DefaultMoreLabel
+	}
+
+	kept := make([]*menu.Item, 0, o.MaxItems+1)
+	var overflow []*menu.Item
+	visible = 0
+	for _, child := range children {
+		switch {
+		case !o.IsVisible(ctx, child):
+			if visible < o.MaxItems {
+				kept = append(kept, child)
+			}
+		case visible < o.MaxItems:
+			kept = append(kept, child)
+			visible++
+		default:
+			overflow = append(overflow, child)
+		}
+	}
+
+	more := o.mores.get(root, label, overflow)
+	if more == nil {
+		return
+	}
+
+	o.more = more
+	o.ordered[root] = append(kept, more)
+	o.ordered[more] = more.Children
+}
+
+// SetMaxItems sets the values of the MaxItems and MoreLabel fields in the Options struct and returns the pointer
+// to the Options struct.
+func (o *Options) SetMaxItems(n int, moreLabel string) *Options {
+	o.MaxItems = n
+	o.MoreLabel = moreLabel
+	o.mores = &moreCache{}
+	return o
+}
+
+// WithMaxItems is a function that returns an Option for rendering n top level items at most, the overflow
+// being collapsed into a trailing branch labeled moreLabel, DefaultMoreLabel when empty, e.g. for bounded
+// navbars. The branch is rendered like the other branches, e.g. as a dropdown by the Bootstrap templates,
+// its items being one level below it. The branch does not count against the Depth option, so its items
+// are rendered whenever the top level items are.
+// A value of n of 0 or less renders all the items.
+func WithMaxItems(n int, moreLabel string) Option {
+	return func(options *Options) {
+		options.SetMaxItems(n, moreLabel)
+	}
+}
@@ -0,0 +1,145 @@
+package renderer
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gowool/menu"
+)
+
+func moreTree(t *testing.T) *menu.Item {
+	t.Helper()
+
+	root, err := menu.NewItem("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		child, err := root.AddChild(name, menu.WithURI("/"+name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name == "d" {
+			if _, err = child.AddChild("d1", menu.WithURI("/d/1")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return root
+}
+
+func TestMaxItemsLevels(t *testing.T) {
+	root := moreTree(t)
+	matcher := menu.NewCoreMatcher(menu.URLVoter{})
+	ctx := menu.WithRequestURL(context.Background(), &url.URL{Path: "/d/1"})
+
+	r := NewListRenderer(matcher,
+		WithMaxItems(2, ""),
+		WithCompressed(true),
+		WithLevelOptions(map[int][]Option{2: {WithLeafClass("sub")}}),
+	)
+	html, err := r.Render(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`<li class="current-ancestor last"><span>More</span><ul class="menu-level-1">`,
+		`<li class="first sub"><a href="/c">`,
+		`<li class="current-ancestor last"><a href="/d"></a><ul class="menu-level-2">`,
+		`<li class="current first last"><a href="/d/1">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Render() = %s, want it to contain %s", html, want)
+		}
+	}
+
+	paths := DryRun(ctx, matcher, root, WithMaxItems(2, ""))
+	want := []string{"root/a", "root/b", "root/more", "root/more/c", "root/more/d", "root/more/d/d1"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("DryRun() = %v, want %v", paths, want)
+	}
+}
+
+func TestMaxItemsDepth(t *testing.T) {
+	root := moreTree(t)
+	matcher := menu.NewCoreMatcher(menu.URLVoter{})
+	ctx := context.Background()
+
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{depth: 1, want: []string{"root/a", "root/b", "root/more", "root/more/c", "root/more/d"}},
+		{depth: 2, want: []string{"root/a", "root/b", "root/more", "root/more/c", "root/more/d", "root/more/d/d1"}},
+	}
+	for _, tt := range tests {
+		paths := DryRun(ctx, matcher, root, WithMaxItems(2, ""), WithDepth(&tt.depth))
+		if !slices.Equal(paths, tt.want) {
+			t.Errorf("DryRun(depth %d) = %v, want %v", tt.depth, paths, tt.want)
+		}
+
+		html, err := NewListRenderer(matcher, WithMaxItems(2, ""), WithDepth(&tt.depth)).Render(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(html, `href="/d"`) {
+			t.Errorf("Render(depth %d) = %s, want the overflow rendered", tt.depth, html)
+		}
+		if got := strings.Contains(html, `href="/d/1"`); got != (tt.depth > 1) {
+			t.Errorf("Render(depth %d) renders the children of the overflow: %t", tt.depth, got)
+		}
+	}
+}
+
+func TestMaxItemsStableBranch(t *testing.T) {
+	root := moreTree(t)
+	ctx := context.Background()
+	options := NewOptions(WithMaxItems(2, ""))
+
+	first := options.Copy()
+	first.prepare(ctx, nil, root)
+	second := options.Copy()
+	second.prepare(ctx, nil, root)
+
+	if first.more == nil || first.more != second.more {
+		t.Fatalf("prepare() built the branches %p and %p, want the same one", first.more, second.more)
+	}
+	for _, child := range first.more.Children {
+		if child.Parent != first.more || child.Level() != 2 {
+			t.Errorf("item %s has the parent %v and the level %d, want the branch and 2", child.Name, child.Parent, child.Level())
+		}
+	}
+	for _, child := range root.Children {
+		if child.Parent != root {
+			t.Errorf("item %s was moved out of the tree", child.Name)
+		}
+	}
+}
+
+func TestMaxItemsEditedOverflow(t *testing.T) {
+	root := moreTree(t)
+	r := NewListRenderer(menu.NewCoreMatcher(menu.URLVoter{}), WithMaxItems(2, ""), WithClearMatcher(true))
+
+	if _, err := r.Render(context.Background(), root); err != nil {
+		t.Fatal(err)
+	}
+
+	d := root.Children[3]
+	d.SetIsCurrent()
+	d.Label = "D-renamed"
+
+	html, err := r.Render(context.Background(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, `<a href="/d">D-renamed</a>`) {
+		t.Errorf("Render() = %s, want the edited label of the overflow item", html)
+	}
+	if !strings.Contains(html, `class="current`) {
+		t.Errorf("Render() = %s, want the edited overflow item current", html)
+	}
+}
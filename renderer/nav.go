@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"context"
+	"maps"
+
+	"github.com/gowool/menu"
+)
+
+// Nav describes the <nav> element wrapping a rendered menu, configured with the Nav option, so the consumers
+// do not have to post-process the returned HTML. The attributes of the element are the Attributes of the rendered
+// root item, which are not used otherwise, completed with the fields of the Nav option.
+// The SkipLink option is rendered before the element, the Brand option and the truncation marker of the Budget
+// option inside it.
+//
+// Example usage:
+//
+//	html, err := r.Render(ctx, root, renderer.WithNav(&renderer.Nav{AriaLabel: "Main", ID: "main-nav"}))
+type Nav struct {
+	// AriaLabel is the aria-label of the element, distinguishing the menus of a page for screen readers.
+	// The aria-label attribute of the root item is used when empty, otherwise its label.
+	AriaLabel string `json:"aria_label,omitempty"`
+	// ID is the id of the element, the id attribute of the root item is used when empty.
+	ID string `json:"id,omitempty"`
+	// Class is the class of the element, appended to the class attribute of the root item.
+	Class string `json:"class,omitempty"`
+	// Attributes are other attributes of the element, overriding the attributes of the root item.
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// NavAttributes returns the attributes of the <nav> element wrapping the item, see Nav,
+// or nil if the Nav option is not set.
+func (o *Options) NavAttributes(ctx context.Context, item *menu.Item) map[string]any {
+	if o.Nav == nil {
+		return nil
+	}
+
+	attributes := maps.Clone(item.Attributes)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	maps.Copy(attributes, o.Nav.Attributes)
+
+	switch {
+	case o.Nav.AriaLabel != "":
+		attributes["aria-label"] = o.Nav.AriaLabel
+	case attributes["aria-label"] == nil:
+		if label := item.ResolveLabel(ctx, nil); label != "" {
+			attributes["aria-label"] = label
+		}
+	}
+	if o.Nav.ID != "" {
+		attributes["id"] = o.Nav.ID
+	}
+	if o.Nav.Class != "" {
+		mergeClass(attributes, o.Nav.Class)
+	}
+	return attributes
+}
+
+// SetNav sets the value of the Nav field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetNav(nav *Nav) *Options {
+	o.Nav = nav
+	return o
+}
+
+// WithNav is a function that returns an Option for wrapping the menu in a <nav> element, e.g.
+// WithNav(&Nav{AriaLabel: "Main"}). A nil nav renders no wrapper.
+func WithNav(nav *Nav) Option {
+	return func(options *Options) {
+		options.SetNav(nav)
+	}
+}
@@ -1,5 +1,11 @@
 package renderer
 
+import (
+	"slices"
+
+	"github.com/gowool/menu"
+)
+
 // Option represents a function that modifies an *Options object.
 //
 // Usage example:
@@ -25,7 +31,8 @@ func WithDepth(depth *int) Option {
 		if depth == nil {
 			options.Depth = nil
 		} else {
-			*options.Depth = *depth
+			value := *depth
+			options.Depth = &value
 		}
 	}
 }
@@ -41,11 +48,79 @@ func WithMatchingDepth(matchingDepth *int) Option {
 		if matchingDepth == nil {
 			options.MatchingDepth = nil
 		} else {
-			*options.MatchingDepth = *matchingDepth
+			value := *matchingDepth
+			options.MatchingDepth = &value
+		}
+	}
+}
+
+// WithCurrentBranchDepth is a function that returns an Option for rendering only the branch of the current item
+// (contextual menus): the children of its ancestors and its descendants up to depth levels.
+// When no item is current, the root is used as the current item. A nil depth disables the pruning.
+//
+// Example usage:
+//
+//	depth := 1
+//	renderer.Render(ctx, item, WithCurrentBranchDepth(&depth))
+func WithCurrentBranchDepth(depth *int) Option {
+	return func(options *Options) {
+		if depth == nil {
+			options.CurrentBranchDepth = nil
+		} else {
+			value := *depth
+			options.CurrentBranchDepth = &value
 		}
 	}
 }
 
+// WithChannel is a function that returns an Option for setting the channel the menu is rendered in,
+// e.g. menu.ChannelSitemap. Items hidden in the channel with menu.WithVisibleIn are not rendered.
+// An empty channel renders all items.
+func WithChannel(channel string) Option {
+	return func(options *Options) {
+		options.SetChannel(channel)
+	}
+}
+
+// WithRejectUnsafeURIs is a function that returns an Option for replacing the URIs able to run code,
+// such as javascript:, vbscript: and data: URIs, by UnsafeURI.
+func WithRejectUnsafeURIs(reject bool) Option {
+	return func(options *Options) {
+		options.SetRejectUnsafeURIs(reject)
+	}
+}
+
+// WithURIPolicy is a function that returns an Option for rendering without a link the items whose URI
+// is rejected by the policy, e.g. &menu.DefaultURIPolicy. A nil policy allows all the URIs.
+func WithURIPolicy(policy *menu.URIPolicy) Option {
+	return func(options *Options) {
+		options.SetURIPolicy(policy)
+	}
+}
+
+// WithBrand is a function that returns an Option for rendering the brand before the list of the menu,
+// e.g. WithBrand(&Brand{Label: "Acme", Logo: "/logo.svg"}). A nil brand renders none.
+func WithBrand(brand *Brand) Option {
+	return func(options *Options) {
+		options.SetBrand(brand)
+	}
+}
+
+// WithFilters is a function that returns an Option adding filters deciding which items are rendered.
+// Unlike pruning a copy of the tree, filters let the renderers decide at render time which items are visible,
+// including the branch/leaf and first/last classes derived from the visible children.
+//
+// Example usage:
+//
+//	renderer.Render(ctx, item, WithFilters(func(ctx context.Context, item *menu.Item) bool {
+//	    return !strings.HasPrefix(item.URI, "/admin") || isAdmin(ctx)
+//	}))
+func WithFilters(filters ...menu.Filter) Option {
+	return func(options *Options) {
+		options.SetFilters(append(slices.Clip(options.Filters), filters...)...)
+	}
+}
+
 // WithCurrentClass is a function that returns an Option function. The returned Option function sets the CurrentClass field of an Options struct.
 // Usage example:
 // options := &Options{}
@@ -155,6 +230,32 @@ func WithClearMatcher(clearMatcher bool) Option {
 	}
 }
 
+// WithRecover is a function that returns an Option for setting the Recover field in the Options struct.
+// When enabled, panics raised while rendering (e.g. by custom themes) are converted into a *PanicError
+// carrying the path of the item being rendered, so one bad item can't take down the whole HTTP handler.
+func WithRecover(recoverPanics bool) Option {
+	return func(options *Options) {
+		options.SetRecover(recoverPanics)
+	}
+}
+
+// WithCompressed is a function that returns an Option for setting the Compressed field in the Options struct,
+// disabling the indentation and new lines of the ListRenderer output.
+func WithCompressed(compressed bool) Option {
+	return func(options *Options) {
+		options.SetCompressed(compressed)
+	}
+}
+
+// WithIndent is a function that returns an Option for setting the indentation of the ListRenderer output per
+// nesting step, e.g. WithIndent(4, " ") for 4 spaces or WithIndent(1, "\t") for tabs. A width of 0 and an empty
+// character fall back to the default of 2 spaces.
+func WithIndent(width int, char string) Option {
+	return func(options *Options) {
+		options.SetIndent(width, char)
+	}
+}
+
 // WithExtras is a function that returns an Option for setting the Extras field in the Options struct.
 // It takes a map[string]any as input and sets the Extras field in the Options struct to the provided map.
 // Usage example:
@@ -195,3 +296,15 @@ func WithExtra(name string, value any) Option {
 		options.AddExtra(name, value)
 	}
 }
+
+// WithFallback returns an Option that sets the Fallback field of the Options struct.
+// When set, a renderer that fails emits the fallback content instead of partial output and returns no error.
+//
+// Example usage:
+//
+//	renderer.Render(ctx, item, WithFallback(CommentFallback))
+func WithFallback(fallback FallbackFunc) Option {
+	return func(options *Options) {
+		options.SetFallback(fallback)
+	}
+}
@@ -1,6 +1,11 @@
 package renderer
 
-import "maps"
+import (
+	"html/template"
+	"maps"
+
+	"github.com/gowool/menu"
+)
 
 type Options struct {
 	Depth           *int           `json:"depth,omitempty"`
@@ -14,7 +19,107 @@ type Options struct {
 	CurrentAsLink   bool           `json:"current_as_link,omitempty"`
 	AllowSafeLabels bool           `json:"allow_safe_labels,omitempty"`
 	ClearMatcher    bool           `json:"clear_matcher,omitempty"`
+	Recover         bool           `json:"recover,omitempty"`
+	Compressed      bool           `json:"compressed,omitempty"`
 	Extras          map[string]any `json:"extras,omitempty"`
+	Fallback        FallbackFunc   `json:"-"`
+
+	// AncestorDepthPrefix adds the distance from the current item to the classes of its ancestors,
+	// e.g. "current-ancestor-" for current-ancestor-1 and current-ancestor-2, see Options.AncestorDepthClass.
+	AncestorDepthPrefix string `json:"ancestor_depth_prefix,omitempty"`
+
+	// IndentWidth and IndentChar set the indentation of the ListRenderer output per nesting step, a list item
+	// in its list or a link or list in its list item, 2 spaces by default, e.g. 4 and " ", or 1 and "\t" for tabs.
+	IndentWidth int    `json:"indent_width,omitempty"`
+	IndentChar  string `json:"indent_char,omitempty"`
+
+	// ItemWrapper wraps the HTML of every list item rendered by ListRenderer, see ItemWrapperFunc.
+	ItemWrapper ItemWrapperFunc `json:"-"`
+
+	// ItemClassFunc and LinkAttrFunc compute classes of list elements and attributes of links at render time.
+	ItemClassFunc ItemClassFunc `json:"-"`
+	LinkAttrFunc  LinkAttrFunc  `json:"-"`
+
+	// TemplateFuncs holds the functions exposed to the templates of TemplateRenderer as .Funcs.
+	TemplateFuncs template.FuncMap `json:"-"`
+
+	// CurrentBranchDepth prunes the rendered tree to the branch of the current item: only the children
+	// of its ancestors and its descendants up to CurrentBranchDepth levels are rendered.
+	CurrentBranchDepth *int `json:"current_branch_depth,omitempty"`
+
+	// DropdownMode selects the classes and attributes emitted for branches, see DropdownMode.
+	// HoverClass, ToggleClass and ToggleAttributes are empty by default, Bootstrap5Options sets the ones of Bootstrap.
+	DropdownMode     DropdownMode   `json:"dropdown_mode,omitempty"`
+	HoverClass       string         `json:"hover_class,omitempty"`
+	ToggleClass      string         `json:"toggle_class,omitempty"`
+	ToggleAttributes map[string]any `json:"toggle_attributes,omitempty"`
+
+	// MenuKeys emits the stable key of every item in the data-menu-key attribute of its list element.
+	// The branches whose key is carried by the context are marked with OpenClass, see ContextWithOpenBranches.
+	MenuKeys  bool   `json:"menu_keys,omitempty"`
+	OpenClass string `json:"open_class,omitempty"`
+
+	// Channel names the placement the menu is rendered in, e.g. menu.ChannelNavbar or menu.ChannelFooter,
+	// so the same tree can adapt per placement. Items hidden in the channel are not rendered, see menu.WithVisibleIn.
+	// The channel is attached to the render context for filters and decorators, see menu.ChannelFromContext,
+	// and exposed to templates as .Channel.
+	Channel string `json:"channel,omitempty"`
+
+	// RejectUnsafeURIs replaces the URIs able to run code, such as javascript: URIs, by UnsafeURI, see Options.URI.
+	RejectUnsafeURIs bool `json:"reject_unsafe_uris,omitempty"`
+
+	// URIPolicy omits the links of the items whose URI it rejects, see Options.URI.
+	URIPolicy *menu.URIPolicy `json:"uri_policy,omitempty"`
+
+	// Brand is rendered before the list of the menu, e.g. the logo of a navbar, see Brand.
+	Brand *Brand `json:"brand,omitempty"`
+
+	// Nav wraps the menu in a <nav> element, see Nav.
+	Nav *Nav `json:"nav,omitempty"`
+
+	// LevelTemplates holds the templates rendering the items of TemplateRenderer per level, see Options.ItemTemplate.
+	LevelTemplates map[int]string `json:"level_templates,omitempty"`
+
+	// LevelOptions overrides the options for the items of a level, see Options.ForLevel.
+	LevelOptions map[int][]Option `json:"-"`
+
+	// StartLevel and RootFromCurrent render the children of an item on the trail of the current item
+	// instead of the children of the rendered item, see WithStartLevel and WithRootFromCurrent.
+	StartLevel      int  `json:"start_level,omitempty"`
+	RootFromCurrent bool `json:"root_from_current,omitempty"`
+
+	// MaxItems collapses the top level items beyond it into a trailing branch labeled MoreLabel, see WithMaxItems.
+	MaxItems  int    `json:"max_items,omitempty"`
+	MoreLabel string `json:"more_label,omitempty"`
+
+	// Budget caps the number of rendered items and levels, see Budget.
+	Budget *Budget `json:"budget,omitempty"`
+
+	// SkipLink is rendered before the menu, letting users jump to the main content, see SkipLink.
+	// Landmarks gives an id to the list of the menu and to its top level items, see Options.LandmarkID.
+	SkipLink  *SkipLink `json:"skip_link,omitempty"`
+	Landmarks bool      `json:"landmarks,omitempty"`
+
+	// Order renders the children of the items in a random order, see OrderMode and Options.Children.
+	Order OrderMode `json:"order,omitempty"`
+
+	// Hidden selects how the items hidden with Display set to false are rendered, see HiddenMode.
+	Hidden HiddenMode `json:"hidden,omitempty"`
+
+	// EmbedState embeds the navigation state of the menu for client side routers, see MenuState.
+	EmbedState bool `json:"embed_state,omitempty"`
+
+	// Filters hides the items they reject, see menu.Filter.
+	Filters []menu.Filter `json:"-"`
+
+	root      *menu.Item
+	unleveled *Options
+	more      *menu.Item
+	mores     *moreCache
+	ordered   map[*menu.Item][]*menu.Item
+	expanded  map[*menu.Item]bool
+	granted   map[*menu.Item]bool
+	truncated bool
 }
 
 // NewOptions creates a new instance of Options with default values and applies the provided options.
@@ -54,6 +159,8 @@ func NewOptions(options ...Option) *Options {
 		LastClass:     "last",
 		CurrentAsLink: true,
 		ClearMatcher:  true,
+		Channel:       menu.ChannelMenu,
+		OpenClass:     "open",
 		Extras:        map[string]any{},
 	}
 	return o.Apply(options...)
@@ -101,6 +208,43 @@ func (o *Options) SetMatchingDepth(matchingDepth int) *Options {
 	return o
 }
 
+// SetCurrentBranchDepth sets the value of the CurrentBranchDepth field in the Options struct and returns
+// the pointer to the Options struct.
+func (o *Options) SetCurrentBranchDepth(depth int) *Options {
+	o.CurrentBranchDepth = &depth
+	return o
+}
+
+// SetRejectUnsafeURIs sets the value of the RejectUnsafeURIs field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetRejectUnsafeURIs(reject bool) *Options {
+	o.RejectUnsafeURIs = reject
+	return o
+}
+
+// SetURIPolicy sets the value of the URIPolicy field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetURIPolicy(policy *menu.URIPolicy) *Options {
+	o.URIPolicy = policy
+	return o
+}
+
+// SetBrand sets the value of the Brand field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetBrand(brand *Brand) *Options {
+	o.Brand = brand
+	return o
+}
+
+// SetChannel sets the value of the Channel field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetChannel(channel string) *Options {
+	o.Channel = channel
+	return o
+}
+
+// SetFilters replaces the filters deciding which items are visible and returns the pointer to the Options struct.
+func (o *Options) SetFilters(filters ...menu.Filter) *Options {
+	o.Filters = filters
+	return o
+}
+
 // SetCurrentClass sets the value of the CurrentClass field in the Options struct and returns the modified Options struct.
 func (o *Options) SetCurrentClass(currentClass string) *Options {
 	o.CurrentClass = currentClass
@@ -196,6 +340,27 @@ func (o *Options) SetClearMatcher(clearMatcher bool) *Options {
 	return o
 }
 
+// SetRecover sets the `Recover` field in the `Options` struct and returns a pointer to the modified struct.
+// With Recover enabled, a panic during rendering is returned as a *PanicError instead of crashing the caller.
+func (o *Options) SetRecover(recoverPanics bool) *Options {
+	o.Recover = recoverPanics
+	return o
+}
+
+// SetCompressed sets the `Compressed` field in the `Options` struct and returns a pointer to the modified struct.
+// With Compressed enabled, ListRenderer writes its output without indentation nor new lines.
+func (o *Options) SetCompressed(compressed bool) *Options {
+	o.Compressed = compressed
+	return o
+}
+
+// SetIndent sets the `IndentWidth` and `IndentChar` fields in the `Options` struct and returns a pointer to the modified struct.
+func (o *Options) SetIndent(width int, char string) *Options {
+	o.IndentWidth = width
+	o.IndentChar = char
+	return o
+}
+
 // SetExtras sets the extras map for the Options object.
 // If the provided extras map is nil, it sets an empty map for extras.
 // Otherwise, it clones the provided extras map and sets it as extras.
@@ -209,6 +374,13 @@ func (o *Options) SetExtras(extras map[string]any) *Options {
 	return o
 }
 
+// SetFallback sets the function rendering the replacement content of a menu whose rendering failed
+// and returns a pointer to the modified Options struct. A nil fallback makes the renderer return the error.
+func (o *Options) SetFallback(fallback FallbackFunc) *Options {
+	o.Fallback = fallback
+	return o
+}
+
 // AddExtra adds an extra value to the Options.Extras map.
 //
 // Parameters:
@@ -223,10 +395,19 @@ func (o *Options) AddExtra(name string, value any) *Options {
 }
 
 // Extra returns the value of the specified extra property from the Options struct. If the property is not found, it returns the default value.
+// The extras declared in OptionExtras are also read from their key and their aliases, e.g. "render.template" and "template".
+// Built-in extras should be read with their typed accessors, e.g. ListElementExtra.Get(options).
 func (o *Options) Extra(name string, def ...any) any {
 	if value, ok := o.Extras[name]; ok {
 		return value
 	}
+	if info, ok := OptionExtras.Lookup(name); ok {
+		for _, key := range append([]string{info.Key}, info.Aliases...) {
+			if value, ok := o.Extras[key]; ok {
+				return value
+			}
+		}
+	}
 	if len(def) > 0 {
 		return def[0]
 	}
@@ -252,7 +433,14 @@ func (o *Options) Copy() *Options {
 		depth := *o.MatchingDepth
 		newOptions.MatchingDepth = &depth
 	}
+	if o.CurrentBranchDepth != nil {
+		depth := *o.CurrentBranchDepth
+		newOptions.CurrentBranchDepth = &depth
+	}
 	newOptions.Extras = maps.Clone(o.Extras)
+	newOptions.ToggleAttributes = maps.Clone(o.ToggleAttributes)
+	newOptions.LevelTemplates = maps.Clone(o.LevelTemplates)
+	newOptions.LevelOptions = maps.Clone(o.LevelOptions)
 
 	return &newOptions
 }
@@ -274,12 +462,48 @@ func (o *Options) Slice() []Option {
 		WithMatchingDepth(o.MatchingDepth),
 		WithCurrentClass(o.CurrentClass),
 		WithAncestorClass(o.AncestorClass),
+		WithAncestorDepthPrefix(o.AncestorDepthPrefix),
 		WithFirstClass(o.FirstClass),
 		WithLastClass(o.LastClass),
 		WithLeafClass(o.LeafClass),
 		WithBranchClass(o.BranchClass),
+		WithCurrentAsLink(o.CurrentAsLink),
 		WithAllowSafeLabels(o.AllowSafeLabels),
 		WithClearMatcher(o.ClearMatcher),
+		WithRecover(o.Recover),
+		WithCompressed(o.Compressed),
+		WithIndent(o.IndentWidth, o.IndentChar),
 		WithExtras(o.Extras),
+		WithFallback(o.Fallback),
+		WithItemWrapper(o.ItemWrapper),
+		WithItemClassFunc(o.ItemClassFunc),
+		WithLinkAttrFunc(o.LinkAttrFunc),
+		WithCurrentBranchDepth(o.CurrentBranchDepth),
+		WithDropdownMode(o.DropdownMode),
+		WithHoverClass(o.HoverClass),
+		WithToggleClass(o.ToggleClass),
+		WithToggleAttributes(o.ToggleAttributes),
+		WithMenuKeys(o.MenuKeys),
+		WithOpenClass(o.OpenClass),
+		WithChannel(o.Channel),
+		WithRejectUnsafeURIs(o.RejectUnsafeURIs),
+		WithURIPolicy(o.URIPolicy),
+		WithBrand(o.Brand),
+		WithNav(o.Nav),
+		WithLevelTemplates(o.LevelTemplates),
+		WithLevelOptions(o.LevelOptions),
+		WithStartLevel(o.StartLevel),
+		WithRootFromCurrent(o.RootFromCurrent),
+		WithMaxItems(o.MaxItems, o.MoreLabel),
+		WithOrder(o.Order),
+		WithHidden(o.Hidden),
+		WithBudget(o.Budget),
+		WithSkipLink(o.SkipLink),
+		WithLandmarks(o.Landmarks),
+		WithEmbedState(o.EmbedState),
+		func(options *Options) {
+			options.SetFilters(o.Filters...)
+			options.SetTemplateFuncs(o.TemplateFuncs)
+		},
 	}
 }
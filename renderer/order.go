@@ -0,0 +1,133 @@
+package renderer
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/gowool/menu"
+)
+
+// OrderMode selects the order the children of the items are rendered in, see the Order option.
+type OrderMode string
+
+const (
+	// OrderNone renders the children in the order of the tree.
+	OrderNone OrderMode = ""
+	// OrderShuffle renders the children in a random order.
+	OrderShuffle OrderMode = "shuffle"
+	// OrderWeighted renders the children in a random order where the items with a higher WeightExtra
+	// tend to come first, an item of weight 2 being twice as likely as an item of weight 1 to precede the others.
+	// The items with a weight of 0 or less come last, in the order of the tree.
+	OrderWeighted OrderMode = "weighted"
+)
+
+// WeightExtra holds the weight of an item for the OrderWeighted order, 1 by default.
+var WeightExtra = menu.DefineExtra("renderer", menu.ExtraKey("render", "weight"), 1.0, "weight")
+
+// WithWeight is a function that returns a menu.Option setting the WeightExtra of an Item.
+func WithWeight(weight float64) menu.Option {
+	return WeightExtra.Option(weight)
+}
+
+type orderSeedKey struct{}
+
+// ContextWithOrderSeed returns a copy of the context carrying the seed of the random orders, see the Order option,
+// e.g. derived from the user or the session so the rotated links of a user stay stable across requests.
+// Without seed, the order changes at every render.
+func ContextWithOrderSeed(ctx context.Context, seed uint64) context.Context {
+	return context.WithValue(ctx, orderSeedKey{}, seed)
+}
+
+// Children returns the children of the item in the order they are rendered in: the order of the tree,
+// or a random order when the Order option is set, e.g. for the rotation of promotional links.
+// The random order of an item is stable during a render and, with the same seed, across renders,
+// see ContextWithOrderSeed. The tree is never modified, so the matching of the current item is unaffected.
+// With the HiddenChildren mode of the Hidden option, the hidden children are replaced with their own children,
+// and with the MaxItems option, the overflow of the children of the rendered item is moved to a "more" branch.
+func (o *Options) Children(ctx context.Context, item *menu.Item) []*menu.Item {
+	if children, ok := o.ordered[item]; ok {
+		return children
+	}
+	if o.Hidden != HiddenChildren && (o.Order == OrderNone || len(item.Children) < 2) {
+		return item.Children
+	}
+
+	children := item.Children
+	if o.Hidden == HiddenChildren {
+		children = liftHidden(item.Children)
+	}
+	if o.Order != OrderNone && len(children) > 1 {
+		seed, ok := ctx.Value(orderSeedKey{}).(uint64)
+		if !ok {
+			seed = rand.Uint64()
+		}
+		children = orderChildren(item, children, o.Order, seed)
+	}
+
+	if o.ordered != nil {
+		o.ordered[item] = children
+	}
+	return children
+}
+
+// orderChildren returns a copy of the children of the item in a random order seeded with the seed
+// and the key of the item, so the branches of a menu are ordered independently.
+func orderChildren(item *menu.Item, children []*menu.Item, mode OrderMode, seed uint64) []*menu.Item {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(item.Key()))
+	rng := rand.New(rand.NewPCG(seed, h.Sum64()))
+
+	children = slices.Clone(children)
+	if mode != OrderWeighted {
+		rng.Shuffle(len(children), func(i, j int) {
+			children[i], children[j] = children[j], children[i]
+		})
+		return children
+	}
+
+	// weighted sampling without replacement of Efraimidis and Spirakis: the items are sorted by u^(1/weight)
+	keys := make(map[*menu.Item]float64, len(children))
+	for _, child := range children {
+		if weight := WeightExtra.Get(child); weight > 0 {
+			keys[child] = math.Pow(rng.Float64(), 1/weight)
+		} else {
+			keys[child] = -1
+		}
+	}
+	slices.SortStableFunc(children, func(a, b *menu.Item) int {
+		switch {
+		case keys[a] > keys[b]:
+			return -1
+		case keys[a] < keys[b]:
+			return 1
+		}
+		return 0
+	})
+	return children
+}
+
+// prepareOrder resets the orders of the children, and the children lifted by the Hidden option or collapsed
+// by the MaxItems option, computed during the previous render.
+func (o *Options) prepareOrder() {
+	o.ordered = nil
+	if o.Order != OrderNone || o.Hidden == HiddenChildren || o.MaxItems > 0 {
+		o.ordered = map[*menu.Item][]*menu.Item{}
+	}
+}
+
+// SetOrder sets the value of the Order field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetOrder(order OrderMode) *Options {
+	o.Order = order
+	return o
+}
+
+// WithOrder is a function that returns an Option for rendering the children of the items in a random order,
+// e.g. WithOrder(OrderWeighted) for the rotation of promotional links, see Options.Children.
+func WithOrder(order OrderMode) Option {
+	return func(options *Options) {
+		options.SetOrder(order)
+	}
+}
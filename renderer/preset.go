@@ -0,0 +1,29 @@
+package renderer
+
+import (
+	"slices"
+	"sync"
+)
+
+var presets = struct {
+	mu sync.RWMutex
+	m  map[string][]Option
+}{m: map[string][]Option{}}
+
+// RegisterPreset registers a named set of options, e.g. the classes used by a CSS framework,
+// which can be referenced by name from a menu configuration. Registering an existing name replaces it.
+func RegisterPreset(name string, options ...Option) {
+	presets.mu.Lock()
+	defer presets.mu.Unlock()
+
+	presets.m[name] = slices.Clone(options)
+}
+
+// Preset returns the options of a registered preset.
+func Preset(name string) ([]Option, bool) {
+	presets.mu.RLock()
+	defer presets.mu.RUnlock()
+
+	options, ok := presets.m[name]
+	return slices.Clone(options), ok
+}
@@ -1,7 +1,13 @@
 package renderer
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"runtime/pprof"
+	"strings"
 
 	"github.com/gowool/menu"
 )
@@ -14,3 +20,164 @@ import (
 type Renderer interface {
 	Render(ctx context.Context, item *menu.Item, options ...Option) (string, error)
 }
+
+// WriterRenderer is implemented by the renderers able to stream their output, e.g. large menus written
+// to an http.ResponseWriter without building the whole HTML in memory first.
+type WriterRenderer interface {
+	RenderTo(ctx context.Context, w io.Writer, item *menu.Item, options ...Option) error
+}
+
+// RenderTo renders the item with the given renderer and writes the output to w,
+// streaming it when the renderer implements WriterRenderer.
+//
+// Example usage:
+//
+//	err := renderer.RenderTo(ctx, w, r, root, renderer.WithDepth(2))
+func RenderTo(ctx context.Context, w io.Writer, r Renderer, item *menu.Item, options ...Option) error {
+	if wr, ok := r.(WriterRenderer); ok {
+		return wr.RenderTo(ctx, w, item, options...)
+	}
+
+	content, err := r.Render(ctx, item, options...)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+// run renders the item with fn under the pprof labels of the renderer, applying the Recover and Fallback options:
+// on failure, the content of the fallback is returned if set, otherwise an empty string and the error.
+// The Channel option is attached to the context passed to fn, see menu.ChannelFromContext.
+func run(ctx context.Context, renderer string, item *menu.Item, options *Options, fn func(ctx context.Context) (string, error)) (string, error) {
+	if options.Channel != "" {
+		ctx = menu.ContextWithChannel(ctx, options.Channel)
+	}
+
+	var (
+		content string
+		err     error
+	)
+	if perr := guard(options, item, func() {
+		withLabels(ctx, renderer, item, func(ctx context.Context) {
+			content, err = fn(ctx)
+		})
+	}); perr != nil {
+		err = perr
+	}
+
+	if err != nil {
+		if options.Fallback != nil {
+			return options.Fallback(ctx, item, err), nil
+		}
+		return "", err
+	}
+
+	return content, nil
+}
+
+// writer is the output of the renderers streaming their content, see runTo.
+type writer interface {
+	io.Writer
+	io.StringWriter
+}
+
+// runTo is the streaming counterpart of run: fn writes the output to a buffered writer flushed into w.
+// When the Fallback option is set, the output is buffered entirely instead, so that it can be replaced by
+// the content of the fallback on failure. Otherwise, the output written before a failure is kept.
+func runTo(ctx context.Context, renderer string, w io.Writer, item *menu.Item, options *Options, fn func(ctx context.Context, w writer) error) error {
+	if options.Fallback != nil {
+		content, err := run(ctx, renderer, item, options, func(ctx context.Context) (string, error) {
+			var b strings.Builder
+			err := fn(ctx, &b)
+			return b.String(), err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, content)
+		return err
+	}
+
+	if options.Channel != "" {
+		ctx = menu.ContextWithChannel(ctx, options.Channel)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	var err error
+	if perr := guard(options, item, func() {
+		withLabels(ctx, renderer, item, func(ctx context.Context) {
+			err = fn(ctx, bw)
+		})
+	}); perr != nil {
+		err = perr
+	}
+
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// label returns the label of the item, see Options.Label.
+func label(ctx context.Context, item *menu.Item, options *Options) string {
+	return string(options.Label(ctx, item))
+}
+
+// withLabels runs fn with the pprof labels "menu" and "renderer" attached to the context,
+// so CPU profiles of a server can be broken down per rendered menu and per renderer type.
+func withLabels(ctx context.Context, renderer string, item *menu.Item, fn func(ctx context.Context)) {
+	pprof.Do(ctx, pprof.Labels("menu", item.String(), "renderer", renderer), fn)
+}
+
+// PanicError is the error returned by a renderer with the Recover option enabled when rendering panics.
+type PanicError struct {
+	// Path holds the item names from the root to the item being rendered when the panic occurred.
+	Path []string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func newPanicError(item *menu.Item, value any) *PanicError {
+	if err, ok := value.(*PanicError); ok {
+		return err
+	}
+
+	return &PanicError{Path: item.Path(), Value: value, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("menu: panic while rendering item %q: %v", strings.Join(e.Path, "/"), e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// guard calls fn and, when the Recover option is set, converts a panic into a *PanicError.
+func guard(options *Options, item *menu.Item, fn func()) (err error) {
+	if options.Recover {
+		defer func() {
+			if v := recover(); v != nil {
+				err = newPanicError(item, v)
+			}
+		}()
+	}
+	fn()
+	return nil
+}
+
+// annotatePanic is deferred by renderers walking the tree, so a recovered panic reports the innermost item.
+func annotatePanic(options *Options, item *menu.Item) {
+	if !options.Recover {
+		return
+	}
+	if v := recover(); v != nil {
+		panic(newPanicError(item, v))
+	}
+}
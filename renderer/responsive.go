@@ -0,0 +1,81 @@
+package renderer
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gowool/menu"
+)
+
+var _ Renderer = ResponsiveRenderer{}
+
+var (
+	// ResponsiveIDExtra holds the id shared by the outputs of a ResponsiveRenderer, e.g. so the desktop navbar
+	// can reference the mobile off-canvas element with aria-controls.
+	ResponsiveIDExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "responsive_id"), "", "responsive_id")
+
+	// ResponsiveVariantExtra holds the variant rendered by a ResponsiveRenderer: "desktop" or "mobile".
+	ResponsiveVariantExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "responsive_variant"), "", "responsive_variant")
+)
+
+// ResponsiveResult holds the outputs of a ResponsiveRenderer.
+type ResponsiveResult struct {
+	// ID is the id linking both outputs.
+	ID      string
+	Desktop string
+	Mobile  string
+}
+
+// ResponsiveRenderer renders the same menu twice in one call, e.g. a desktop navbar and a mobile off-canvas,
+// sharing the matcher computation between both renders and linking them with a common id.
+// Both renderers should use the same matcher so the second render reuses the current items found by the first one.
+type ResponsiveRenderer struct {
+	desktop Renderer
+	mobile  Renderer
+}
+
+// NewResponsiveRenderer creates a new ResponsiveRenderer from the desktop and mobile renderers.
+func NewResponsiveRenderer(desktop, mobile Renderer) ResponsiveRenderer {
+	return ResponsiveRenderer{
+		desktop: desktop,
+		mobile:  mobile,
+	}
+}
+
+// RenderResponsive renders the desktop and mobile variants of the menu. The id linking them is the ID of the item
+// or, if it has none, derived from its name. It is passed to both renderers with ResponsiveIDExtra and the variant
+// with ResponsiveVariantExtra. The matcher is only cleared by the mobile render, according to its options.
+func (r ResponsiveRenderer) RenderResponsive(ctx context.Context, item *menu.Item, options ...Option) (ResponsiveResult, error) {
+	result := ResponsiveResult{ID: item.ID}
+	if result.ID == "" {
+		result.ID = "menu-" + item.String()
+	}
+
+	desktop := slices.Concat(options, []Option{
+		ResponsiveIDExtra.Option(result.ID),
+		ResponsiveVariantExtra.Option("desktop"),
+		WithClearMatcher(false),
+	})
+	mobile := slices.Concat(options, []Option{
+		ResponsiveIDExtra.Option(result.ID),
+		ResponsiveVariantExtra.Option("mobile"),
+	})
+
+	var err error
+	if result.Desktop, err = r.desktop.Render(ctx, item, desktop...); err != nil {
+		return result, err
+	}
+	if result.Mobile, err = r.mobile.Render(ctx, item, mobile...); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Render renders both variants and returns them concatenated, desktop first.
+func (r ResponsiveRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	result, err := r.RenderResponsive(ctx, item, options...)
+	if err != nil {
+		return "", err
+	}
+	return result.Desktop + result.Mobile, nil
+}
@@ -0,0 +1,133 @@
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer/htmlutil"
+)
+
+var _ Renderer = SelectRenderer{}
+
+// Select renderer option extras.
+var (
+	// SelectPlaceholderExtra holds the label of an empty first option, none is rendered when empty.
+	SelectPlaceholderExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "select_placeholder"), "", "select_placeholder")
+
+	// SelectIndentExtra holds the prefix repeated before the labels of the options nested deeper than the groups,
+	// two no-break spaces by default since browsers collapse the spaces of options.
+	SelectIndentExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "select_indent"), "\u00a0\u00a0", "select_indent")
+)
+
+// SelectRenderer renders a menu as a <select> element whose options have the URIs of the items as values,
+// e.g. for the navigation of small screens or for forms choosing a position in a menu.
+// The top level items with visible children are rendered as an <optgroup>, starting with an option for the item
+// itself when it has a URI. Deeper items are rendered as options of the group, their labels prefixed with
+// SelectIndentExtra once per extra level. Items without URI are not selectable, only their children are rendered.
+// The current item is selected.
+//
+// The ChildrenAttributes of the rendered item are the attributes of the <select> element, e.g. its name,
+// or an onchange handler navigating to the selected URI.
+//
+// Output example:
+//
+//	<select name="nav">
+//	  <option value="/">Home</option>
+//	  <optgroup label="Blog"><option value="/blog">Blog</option><option value="/blog/news" selected>News</option></optgroup>
+//	</select>
+type SelectRenderer struct {
+	matcher menu.Matcher
+	options *Options
+}
+
+// NewSelectRenderer creates a new SelectRenderer with the given matcher and options.
+func NewSelectRenderer(matcher menu.Matcher, options ...Option) SelectRenderer {
+	return SelectRenderer{
+		matcher: matcher,
+		options: NewOptions(options...),
+	}
+}
+
+// Render renders the <select> element of the item.
+func (r SelectRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	ctx = menu.WithMatcher(ctx, r.matcher)
+	opts := r.options.Copy().Apply(options...)
+	item = opts.prepare(ctx, r.matcher, item)
+
+	content, err := run(ctx, "select", item, opts, func(ctx context.Context) (string, error) {
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("<select%s>", htmlutil.Attributes(maps.Clone(item.ChildrenAttributes))))
+		if placeholder := SelectPlaceholderExtra.Get(opts); placeholder != "" {
+			b.WriteString(fmt.Sprintf(`<option value="">%s</option>`, htmlutil.EncodeHTML(placeholder)))
+		}
+
+		if opts.IsBranch(ctx, item) {
+			sub := opts.SubDepth().SubMatchingDepth()
+			for _, child := range sub.VisibleChildren(ctx, item) {
+				r.renderTopLevel(ctx, &b, child, sub.Copy().skipMore(child))
+			}
+		}
+
+		b.WriteString("</select>")
+		return b.String(), nil
+	})
+
+	if opts.ClearMatcher {
+		r.matcher.Clear()
+	}
+
+	return content, err
+}
+
+// renderTopLevel renders a top level item as an option, or as an optgroup if it is a branch.
+func (r SelectRenderer) renderTopLevel(ctx context.Context, b *strings.Builder, item *menu.Item, options *Options) {
+	defer annotatePanic(options, item)
+
+	if !options.IsBranch(ctx, item) {
+		r.renderOption(ctx, b, item, 0, options)
+		return
+	}
+
+	b.WriteString(fmt.Sprintf(`<optgroup label="%s">`, htmlutil.EncodeAttribute("label", item.ResolveLabel(ctx, nil))))
+	r.renderOption(ctx, b, item, 0, options)
+	r.renderChildren(ctx, b, item, 0, options)
+	b.WriteString("</optgroup>")
+}
+
+// renderChildren renders the visible descendants of the item as options, level being the nesting level of the item
+// below the groups.
+func (r SelectRenderer) renderChildren(ctx context.Context, b *strings.Builder, item *menu.Item, level int, options *Options) {
+	if !options.IsBranch(ctx, item) {
+		return
+	}
+
+	options = options.SubDepth().SubMatchingDepth()
+	for _, child := range options.VisibleChildren(ctx, item) {
+		r.renderOption(ctx, b, child, level, options)
+		r.renderChildren(ctx, b, child, level+1, options.Copy().skipMore(child))
+	}
+}
+
+// renderOption renders the option of an item with a URI, prefixed with the indentation of its level.
+func (r SelectRenderer) renderOption(ctx context.Context, b *strings.Builder, item *menu.Item, level int, options *Options) {
+	uri := options.URI(item.URI)
+	if uri == "" {
+		return
+	}
+
+	var selected string
+	if r.matcher.IsCurrent(ctx, item) {
+		selected = " selected"
+	}
+
+	b.WriteString(fmt.Sprintf(`<option value="%s"%s>%s%s</option>`,
+		htmlutil.EncodeAttribute("value", uri),
+		selected,
+		strings.Repeat(htmlutil.EncodeHTML(SelectIndentExtra.Get(options)), level),
+		// options hold text only, so safe labels are escaped too
+		htmlutil.EncodeHTML(item.ResolveLabel(ctx, nil)),
+	))
+}
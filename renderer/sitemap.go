@@ -0,0 +1,88 @@
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer/htmlutil"
+)
+
+var _ Renderer = SitemapRenderer{}
+
+// SitemapHeadingExtra holds the element used for the headings of the top level items rendered by SitemapRenderer.
+var SitemapHeadingExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "sitemap_heading"), "h2", "sitemap_heading")
+
+// SitemapRenderer renders a print-friendly site map page: a section with a heading per top level item,
+// followed by the nested list of all its descendants. The whole tree is rendered regardless of the
+// Depth option and of DisplayChildren, but hidden items, the items hidden in the Channel and the items
+// rejected by the Filters option are skipped.
+//
+// Output example:
+//
+//	<div class="sitemap">
+//	  <section><h2><a href="/blog">Blog</a></h2><ul><li><a href="/blog/news">News</a></li></ul></section>
+//	</div>
+type SitemapRenderer struct {
+	options *Options
+}
+
+// NewSitemapRenderer creates a new SitemapRenderer with the given options.
+// The Channel option defaults to menu.ChannelSitemap.
+func NewSitemapRenderer(options ...Option) SitemapRenderer {
+	return SitemapRenderer{
+		options: NewOptions(append([]Option{WithChannel(menu.ChannelSitemap)}, options...)...),
+	}
+}
+
+// Render renders the site map of the item.
+func (r SitemapRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	opts := r.options.Copy().Apply(options...)
+
+	return run(ctx, "sitemap", item, opts, func(ctx context.Context) (string, error) {
+		heading := SitemapHeadingExtra.Get(opts)
+
+		var b strings.Builder
+		b.WriteString(`<div class="sitemap">`)
+		for _, child := range opts.VisibleChildren(ctx, item) {
+			b.WriteString(fmt.Sprintf("<section%s>", htmlutil.Attributes(child.Attributes)))
+			b.WriteString(fmt.Sprintf("<%s>%s</%s>", heading, r.renderLink(ctx, child, opts), heading))
+			r.renderList(ctx, &b, child, opts)
+			b.WriteString("</section>")
+		}
+		b.WriteString("</div>")
+
+		return b.String(), nil
+	})
+}
+
+func (r SitemapRenderer) renderList(ctx context.Context, b *strings.Builder, item *menu.Item, options *Options) {
+	children := options.VisibleChildren(ctx, item)
+	if len(children) == 0 {
+		return
+	}
+
+	b.WriteString("<ul>")
+	for _, child := range children {
+		r.renderItem(ctx, b, child, options)
+	}
+	b.WriteString("</ul>")
+}
+
+func (r SitemapRenderer) renderItem(ctx context.Context, b *strings.Builder, item *menu.Item, options *Options) {
+	defer annotatePanic(options, item)
+
+	b.WriteString("<li>")
+	b.WriteString(r.renderLink(ctx, item, options))
+	r.renderList(ctx, b, item, options)
+	b.WriteString("</li>")
+}
+
+func (r SitemapRenderer) renderLink(ctx context.Context, item *menu.Item, options *Options) string {
+	uri := options.URI(item.URI)
+	if uri == "" {
+		return label(ctx, item, options)
+	}
+	return fmt.Sprintf(`<a href="%s">%s</a>`, htmlutil.EncodeAttribute("href", uri), label(ctx, item, options))
+}
@@ -0,0 +1,67 @@
+package renderer
+
+import (
+	"context"
+
+	"github.com/gowool/menu"
+)
+
+// startItem returns the item whose children are rendered in place of the children of the root according to
+// the RootFromCurrent and StartLevel options: the current item, or its ancestor of the StartLevel below the root.
+// When the item is missing, e.g. no item is current, an empty item is returned so nothing is rendered.
+func (o *Options) startItem(ctx context.Context, matcher menu.Matcher, root *menu.Item) *menu.Item {
+	if (o.StartLevel <= 0 && !o.RootFromCurrent) || matcher == nil {
+		return root
+	}
+
+	current := menu.CurrentItem(ctx, matcher, root)
+	if current == nil {
+		return &menu.Item{Name: root.Name}
+	}
+	if o.RootFromCurrent {
+		return current
+	}
+
+	level := root.Level() + o.StartLevel
+	if current.Level() < level {
+		return &menu.Item{Name: root.Name}
+	}
+	for current.Level() > level {
+		current = current.Parent
+	}
+	return current
+}
+
+// SetStartLevel sets the value of the StartLevel field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetStartLevel(level int) *Options {
+	o.StartLevel = level
+	return o
+}
+
+// WithStartLevel is a function that returns an Option for rendering the children of the item of the level
+// on the trail of the current item instead of the children of the rendered item, the top level items being
+// of level 1, e.g. WithStartLevel(1) for a sidebar rendering the active section of a navbar rendered with
+// WithDepth(1). Nothing is rendered when no item of the level is current or an ancestor of a current item.
+// The Depth option counts the levels from the start item, the LevelTemplates and LevelOptions options
+// keep the levels of the tree.
+func WithStartLevel(level int) Option {
+	return func(options *Options) {
+		options.SetStartLevel(level)
+	}
+}
+
+// SetRootFromCurrent sets the value of the RootFromCurrent field in the Options struct and returns the pointer
+// to the Options struct.
+func (o *Options) SetRootFromCurrent(fromCurrent bool) *Options {
+	o.RootFromCurrent = fromCurrent
+	return o
+}
+
+// WithRootFromCurrent is a function that returns an Option for rendering the children of the current item
+// instead of the children of the rendered item, e.g. for the secondary navigation of a page, see
+// menu.ChildrenOfCurrent. Nothing is rendered when no item is current. It takes precedence over StartLevel.
+func WithRootFromCurrent(fromCurrent bool) Option {
+	return func(options *Options) {
+		options.SetRootFromCurrent(fromCurrent)
+	}
+}
@@ -0,0 +1,94 @@
+package renderer
+
+import (
+	"context"
+	"maps"
+
+	"github.com/gowool/menu"
+)
+
+type openBranchesKey struct{}
+
+// ContextWithOpenBranches returns a copy of the context carrying the keys of the branches the user opened,
+// e.g. restored from a cookie, so server-rendered sidebars keep their expanded state across navigations.
+// The keys are the ones emitted in the data-menu-key attribute, see the MenuKeys option.
+func ContextWithOpenBranches(ctx context.Context, keys ...string) context.Context {
+	open := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		open[key] = true
+	}
+	return context.WithValue(ctx, openBranchesKey{}, open)
+}
+
+// OpenBranchesFromContext returns the set of the keys of the open branches carried by the context, or nil.
+func OpenBranchesFromContext(ctx context.Context) map[string]bool {
+	open, _ := ctx.Value(openBranchesKey{}).(map[string]bool)
+	return open
+}
+
+// MenuKey returns the stable key of the item emitted in the data-menu-key attribute, see menu.Item.Key,
+// or an empty string if the MenuKeys option is not set.
+func (o *Options) MenuKey(item *menu.Item) string {
+	if !o.MenuKeys {
+		return ""
+	}
+	return item.Key()
+}
+
+// IsOpen checks if the item is a branch opened by the user, according to the keys carried by the context,
+// see ContextWithOpenBranches.
+func (o *Options) IsOpen(ctx context.Context, item *menu.Item) bool {
+	open := OpenBranchesFromContext(ctx)
+	return open != nil && open[item.Key()] && o.IsBranch(ctx, item)
+}
+
+// OpenClassFor returns the OpenClass option if the item is open, see IsOpen, or an empty string.
+func (o *Options) OpenClassFor(ctx context.Context, item *menu.Item) string {
+	if o.IsOpen(ctx, item) {
+		return o.OpenClass
+	}
+	return ""
+}
+
+// StateAttributes returns a copy of the attributes of the list element of the item completed with
+// its data-menu-key attribute when the MenuKeys option is set.
+func (o *Options) StateAttributes(item *menu.Item, attributes map[string]any) map[string]any {
+	key := o.MenuKey(item)
+	if key == "" {
+		return attributes
+	}
+
+	attributes = maps.Clone(attributes)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	attributes["data-menu-key"] = key
+	return attributes
+}
+
+// SetMenuKeys sets the value of the MenuKeys field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetMenuKeys(menuKeys bool) *Options {
+	o.MenuKeys = menuKeys
+	return o
+}
+
+// SetOpenClass sets the value of the OpenClass field in the Options struct and returns the pointer to the Options struct.
+func (o *Options) SetOpenClass(openClass string) *Options {
+	o.OpenClass = openClass
+	return o
+}
+
+// WithMenuKeys is a function that returns an Option for emitting the stable key of every item
+// in the data-menu-key attribute of its list element, so client side scripts can persist the open branches.
+func WithMenuKeys(menuKeys bool) Option {
+	return func(options *Options) {
+		options.SetMenuKeys(menuKeys)
+	}
+}
+
+// WithOpenClass is a function that returns an Option for setting the class added to the open branches.
+func WithOpenClass(openClass string) Option {
+	return func(options *Options) {
+		options.SetOpenClass(openClass)
+	}
+}
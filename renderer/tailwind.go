@@ -0,0 +1,103 @@
+package renderer
+
+import (
+	"github.com/gowool/menu"
+)
+
+// TailwindTemplate is the template of HTMLTheme rendering a menu styled with utility classes, such as the ones
+// of Tailwind CSS: the elements of the menu carry no class of their own but the classes of a ClassMap,
+// see TailwindClassesExtra, so the menu can be restyled without authoring templates.
+const TailwindTemplate = "@tailwind/menu.html"
+
+// TailwindPreset is the name of the preset registered with TailwindOptions.
+const TailwindPreset = "tailwind"
+
+// ClassMap maps the elements of a rendered menu, "ul", "li", "a" and "span", to their classes per level:
+// the first class applies to the first level, the second to the second level and so on, the last class
+// applying to the deeper levels. The "ul" classes apply to the lists and the "ol" lists alike.
+//
+// Example usage:
+//
+//	classes := renderer.ClassMap{
+//		"ul": {"flex gap-6", "absolute mt-2 w-48 rounded-md bg-white shadow-lg"},
+//		"a":  {"px-3 py-2 text-sm font-medium", "block px-4 py-2 text-sm"},
+//	}
+type ClassMap map[string][]string
+
+// Class returns the class of the element at the level, starting at 1, or an empty string.
+func (m ClassMap) Class(element string, level int) string {
+	classes := m[element]
+	if len(classes) == 0 {
+		return ""
+	}
+	return classes[max(0, min(level, len(classes))-1)]
+}
+
+// DefaultTailwindClasses is the ClassMap of TailwindTemplate when TailwindClassesExtra is not set,
+// a vertical menu turning horizontal on medium screens with its submenus indented below their parent.
+// The elements missing from TailwindClassesExtra also use these classes.
+var DefaultTailwindClasses = ClassMap{
+	"ul": {
+		"flex flex-col gap-1 md:flex-row md:items-center md:gap-2",
+		"mt-1 ml-4 flex flex-col gap-1",
+	},
+	"li": {
+		"relative",
+		"",
+	},
+	"a": {
+		"block rounded-md px-3 py-2 text-sm font-medium text-gray-700 hover:bg-gray-100 hover:text-gray-900",
+		"block rounded-md px-3 py-1.5 text-sm text-gray-600 hover:bg-gray-100 hover:text-gray-900",
+	},
+	"span": {
+		"block px-3 py-2 text-sm font-semibold text-gray-900",
+		"block px-3 py-1.5 text-xs font-semibold uppercase tracking-wide text-gray-500",
+	},
+}
+
+// TailwindClassesExtra holds the ClassMap of the elements rendered by TailwindTemplate.
+var TailwindClassesExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "tailwind_classes"), DefaultTailwindClasses, "tailwind_classes")
+
+func init() {
+	RegisterPreset(TailwindPreset, TailwindOptions()...)
+}
+
+// TailwindOptions returns the options rendering a menu styled with utility classes with TemplateRenderer:
+// the TailwindTemplate template, the DefaultTailwindClasses classes and current and ancestor classes applied to
+// the links. They are registered as the TailwindPreset preset.
+//
+// Example usage:
+//
+//	r := renderer.NewTemplateRenderer(theme, matcher, renderer.TailwindOptions()...)
+//	html, err := r.Render(ctx, root, renderer.WithTailwindClasses(renderer.ClassMap{"a": {"px-2 text-white"}}))
+func TailwindOptions() []Option {
+	return []Option{
+		WithTemplate(TailwindTemplate),
+		WithCurrentClass("bg-gray-100 text-gray-900"),
+		WithAncestorClass("text-gray-900"),
+		WithFirstClass(""),
+		WithLastClass(""),
+		TailwindClassesExtra.Option(DefaultTailwindClasses),
+	}
+}
+
+// WithTailwindClasses returns an Option setting the ClassMap of TailwindTemplate.
+// The elements missing from the map keep the DefaultTailwindClasses classes.
+func WithTailwindClasses(classes ClassMap) Option {
+	return TailwindClassesExtra.Option(classes)
+}
+
+// TailwindClass returns the class of the element of the item rendered by TailwindTemplate, see ClassMap.
+// The level of the "ul" element is the level of the listed children, i.e. the level of the item plus one.
+func (o *Options) TailwindClass(element string, item *menu.Item) string {
+	level := item.Level()
+	if element == "ul" {
+		level++
+	}
+
+	classes := TailwindClassesExtra.Get(o)
+	if _, ok := classes[element]; !ok {
+		classes = DefaultTailwindClasses
+	}
+	return classes.Class(element, level)
+}
@@ -2,13 +2,18 @@ package renderer
 
 import (
 	"context"
-	"html/template"
+	"io"
+	"maps"
+	"slices"
 
 	"github.com/gowool/menu"
-	"github.com/gowool/menu/internal"
+	"github.com/gowool/menu/renderer/htmlutil"
 )
 
-var _ Renderer = TemplateRenderer{}
+var (
+	_ Renderer       = TemplateRenderer{}
+	_ WriterRenderer = TemplateRenderer{}
+)
 
 // MenuTemplate is the constant that holds the path to the menu template file.
 const MenuTemplate = "@menu/menu.html"
@@ -30,13 +35,24 @@ type Theme interface {
 	HTML(ctx context.Context, template string, data any) (string, error)
 }
 
+// WriterTheme is implemented by the themes able to write the generated HTML directly to an io.Writer,
+// used by TemplateRenderer.RenderTo to stream the output.
+type WriterTheme interface {
+	HTMLTo(ctx context.Context, w io.Writer, template string, data any) error
+}
+
+// DataProvider returns extra data exposed to the menu templates, e.g. the current user or a csrf token.
+// It is called once per Render with the rendered item and the effective options.
+type DataProvider func(ctx context.Context, item *menu.Item, options *Options) map[string]any
+
 // TemplateRenderer is a type that represents a renderer for templates.
 // It is used to render HTML templates based on a given theme and matcher.
 // The renderer provides options for customizing the rendering process.
 type TemplateRenderer struct {
-	theme   Theme
-	matcher menu.Matcher
-	options *Options
+	theme     Theme
+	matcher   menu.Matcher
+	options   *Options
+	providers []DataProvider
 }
 
 // NewTemplateRenderer creates a new TemplateRenderer with the given theme, matcher, and options.
@@ -48,29 +64,33 @@ func NewTemplateRenderer(theme Theme, matcher menu.Matcher, options ...Option) T
 	}
 }
 
+// WithDataProviders returns a copy of the renderer with the given data providers registered.
+// The keys returned by the providers are merged into the template data in registration order.
+// Built-in keys (Ctx, Item, Options, Matcher, Channel, Classes, Attributes, Funcs) cannot be overridden.
+func (r TemplateRenderer) WithDataProviders(providers ...DataProvider) TemplateRenderer {
+	r.providers = append(slices.Clip(r.providers), providers...)
+	return r
+}
+
 // Render is a method of the TemplateRenderer struct that renders a menu item using the specified options and theme.
 // It takes a context object, a pointer to a menu.Item object, and a variadic list of options as parameters.
 // It returns a string (the rendered content) and an error (if any occurred during rendering).
 //
 // The function starts by creating a copy of the options and applying the passed options to it.
 // It then calls the HTML method of the theme to render the menu item with the specified template and data.
-// The data passed to the template includes the context object, the menu item, the options, the matcher, and helper functions for converting attributes and classes.
+// The data passed to the template includes the context object, the menu item, the options, the matcher, the channel, and helper functions for converting attributes and classes.
 //
 // If the "ClearMatcher" option is set to true, the matcher is cleared after rendering the content.
 //
-// The rendered content and any error that occurred during rendering are returned as the result of the function.
+// If the theme fails, the partial output is discarded. The content of the Fallback option is returned instead
+// when it is set, otherwise an empty string and the error are returned.
 func (r TemplateRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	ctx = menu.WithMatcher(ctx, r.matcher)
 	opts := r.options.Copy().Apply(options...)
+	item = opts.prepare(ctx, r.matcher, item)
 
-	content, err := r.theme.HTML(ctx, opts.Extra("template", MenuTemplate).(string), map[string]any{
-		"Ctx":     ctx,
-		"Item":    item,
-		"Options": opts,
-		"Matcher": r.matcher,
-		"Classes": internal.HTMLClassesAny,
-		"Attributes": func(attributes map[string]any) template.HTMLAttr {
-			return template.HTMLAttr(internal.HTMLAttributes(attributes))
-		},
+	content, err := run(ctx, "template", item, opts, func(ctx context.Context) (string, error) {
+		return r.theme.HTML(ctx, TemplateExtra.Get(opts), r.data(ctx, item, opts))
 	})
 
 	if opts.ClearMatcher {
@@ -79,3 +99,53 @@ func (r TemplateRenderer) Render(ctx context.Context, item *menu.Item, options .
 
 	return content, err
 }
+
+// RenderTo renders the menu item like Render, but writes the output to w. When the theme implements WriterTheme,
+// the output is streamed, so the HTML written before a failure of the theme is not discarded unless the Fallback
+// option is set.
+func (r TemplateRenderer) RenderTo(ctx context.Context, w io.Writer, item *menu.Item, options ...Option) error {
+	ctx = menu.WithMatcher(ctx, r.matcher)
+	opts := r.options.Copy().Apply(options...)
+	item = opts.prepare(ctx, r.matcher, item)
+
+	err := runTo(ctx, "template", w, item, opts, func(ctx context.Context, w writer) error {
+		name, data := TemplateExtra.Get(opts), r.data(ctx, item, opts)
+
+		if theme, ok := r.theme.(WriterTheme); ok {
+			return theme.HTMLTo(ctx, w, name, data)
+		}
+
+		content, err := r.theme.HTML(ctx, name, data)
+		if err != nil {
+			return err
+		}
+		_, err = w.WriteString(content)
+		return err
+	})
+
+	if opts.ClearMatcher {
+		r.matcher.Clear()
+	}
+
+	return err
+}
+
+// data builds the template data: the values of the registered data providers
+// overlaid with the built-in keys used by the default templates and the TemplateFuncs option.
+func (r TemplateRenderer) data(ctx context.Context, item *menu.Item, options *Options) map[string]any {
+	data := map[string]any{}
+	for _, provider := range r.providers {
+		maps.Copy(data, provider(ctx, item, options))
+	}
+
+	data["Ctx"] = ctx
+	data["Item"] = item
+	data["Options"] = options
+	data["Matcher"] = r.matcher
+	data["Channel"] = options.Channel
+	data["Classes"] = htmlutil.ClassesAny
+	data["Attributes"] = templateAttributes
+	data["Funcs"] = options.TemplateFuncs
+
+	return data
+}
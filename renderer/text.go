@@ -0,0 +1,131 @@
+package renderer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gowool/menu"
+)
+
+var _ Renderer = TextRenderer{}
+
+// Text renderer option extras.
+var (
+	// TextASCIIExtra draws the tree with ASCII characters instead of box-drawing characters.
+	TextASCIIExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "text_ascii"), false, "text_ascii")
+
+	// TextVisibleOnlyExtra skips the hidden items instead of marking them.
+	TextVisibleOnlyExtra = DefineOptionExtra("renderer", menu.ExtraKey("render", "text_visible_only"), false, "text_visible_only")
+)
+
+// treeGlyphs holds the prefixes drawing the branches of a tree: the branch of an item, the branch of the last item,
+// the continuation of a branch below an item and the blank below the last item.
+type treeGlyphs struct {
+	branch, last, pipe, blank string
+}
+
+var (
+	unicodeGlyphs = treeGlyphs{branch: "├── ", last: "└── ", pipe: "│   ", blank: "    "}
+	asciiGlyphs   = treeGlyphs{branch: "|-- ", last: "`-- ", pipe: "|   ", blank: "    "}
+)
+
+// TextRenderer renders a menu as a plain text tree, for debugging loaders and for command line applications.
+// Every item is written on its own line with its label and URI, followed by the markers of its state:
+// [current], [ancestor] and [hidden] for the items that other renderers would skip.
+//
+// Output example:
+//
+//	root
+//	├── Home /
+//	├── Blog /blog [ancestor]
+//	│   └── Article 1 /blog/article-1 [current]
+//	└── About /about
+//
+// The Depth option limits the rendered levels, while DisplayChildren is ignored so that the whole tree is visible.
+type TextRenderer struct {
+	matcher menu.Matcher
+	options *Options
+}
+
+// NewTextRenderer creates a new TextRenderer with the given matcher and options.
+func NewTextRenderer(matcher menu.Matcher, options ...Option) TextRenderer {
+	return TextRenderer{
+		matcher: matcher,
+		options: NewOptions(options...),
+	}
+}
+
+// Render renders the tree of the item.
+func (r TextRenderer) Render(ctx context.Context, item *menu.Item, options ...Option) (string, error) {
+	ctx = menu.WithMatcher(ctx, r.matcher)
+	opts := r.options.Copy().Apply(options...)
+
+	content, err := run(ctx, "text", item, opts, func(ctx context.Context) (string, error) {
+		glyphs := unicodeGlyphs
+		if TextASCIIExtra.Get(opts) {
+			glyphs = asciiGlyphs
+		}
+
+		var b strings.Builder
+		b.WriteString(r.line(ctx, item, opts))
+		b.WriteString("\n")
+		r.renderChildren(ctx, &b, item, "", glyphs, opts)
+		return b.String(), nil
+	})
+
+	if opts.ClearMatcher {
+		r.matcher.Clear()
+	}
+
+	return content, err
+}
+
+func (r TextRenderer) renderChildren(ctx context.Context, b *strings.Builder, item *menu.Item, prefix string, glyphs treeGlyphs, options *Options) {
+	if options.IsStop() {
+		return
+	}
+	options = options.SubDepth().SubMatchingDepth()
+
+	children := options.Children(ctx, item)
+	if TextVisibleOnlyExtra.Get(options) {
+		children = options.VisibleChildren(ctx, item)
+	}
+
+	for i, child := range children {
+		branch, next := glyphs.branch, glyphs.pipe
+		if i == len(children)-1 {
+			branch, next = glyphs.last, glyphs.blank
+		}
+
+		b.WriteString(prefix)
+		b.WriteString(branch)
+		b.WriteString(r.line(ctx, child, options))
+		b.WriteString("\n")
+
+		r.renderChildren(ctx, b, child, prefix+next, glyphs, options.Copy().skipMore(child))
+	}
+}
+
+// line returns the label of the item, or its name without label, followed by its URI and its state markers.
+func (r TextRenderer) line(ctx context.Context, item *menu.Item, options *Options) string {
+	defer annotatePanic(options, item)
+
+	text := item.ResolveLabel(ctx, nil)
+	if text == "" {
+		text = item.Name
+	}
+
+	parts := []string{text}
+	if item.URI != "" {
+		parts = append(parts, item.URI)
+	}
+	if r.matcher.IsCurrent(ctx, item) {
+		parts = append(parts, "[current]")
+	} else if r.matcher.IsAncestor(ctx, item, options.MatchingDepth) {
+		parts = append(parts, "[ancestor]")
+	}
+	if item.Parent != nil && !options.IsVisible(ctx, item) {
+		parts = append(parts, "[hidden]")
+	}
+	return strings.Join(parts, " ")
+}
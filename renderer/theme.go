@@ -0,0 +1,172 @@
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"maps"
+	"strings"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/renderer/htmlutil"
+	"github.com/gowool/menu/views"
+)
+
+var (
+	_ Theme       = HTMLTheme{}
+	_ WriterTheme = HTMLTheme{}
+)
+
+// FuncMap returns the functions the default menu templates depend on:
+//   - raw: marks a string as safe HTML
+//   - attributes: renders a map as HTML attributes sorted by name, or a *menu.Attributes in its order
+//   - classes: joins a list of classes into a class attribute value
+//   - dict, set, merge: build and modify map[string]any values
+//   - list, append: build and modify []any values
+//   - include: executes the template with the given name and data, e.g. a template chosen at render time
+//     such as Options.ItemTemplate, only available in the templates parsed by HTMLTheme and FSTheme
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"raw": func(s string) template.HTML {
+			return template.HTML(s)
+		},
+		"attributes": templateAttributes,
+		"classes":    htmlutil.ClassesAny,
+		"dict":       dict,
+		"set":        set,
+		"merge":      merge,
+		"list":       list,
+		"append":     push,
+		"include": func(name string, _ any) (template.HTML, error) {
+			return "", fmt.Errorf("include %s: not supported by the theme", name)
+		},
+	}
+}
+
+// HTMLTheme is a Theme backed by html/template that renders the templates embedded in the views package.
+type HTMLTheme struct {
+	t *template.Template
+}
+
+// NewHTMLTheme parses the templates embedded in the views package with FuncMap: the default menu templates,
+// the Bootstrap 5 templates, see Bootstrap5Template, and the other built-in template sets.
+// The given funcs are added on top of the default functions, so a caller can override any of them
+// or register the helpers of its own templates (e.g. sprig.FuncMap()).
+// The templates are registered under their path prefixed with "@", e.g. MenuTemplate.
+func NewHTMLTheme(funcs template.FuncMap) (HTMLTheme, error) {
+	t, err := parseTemplates(funcs, views.FS)
+	if err != nil {
+		return HTMLTheme{}, err
+	}
+	return HTMLTheme{t: t}, nil
+}
+
+// parseTemplates parses the "*/*.html" templates of the file systems with FuncMap and the given funcs.
+// A template found in several file systems is parsed from the first one.
+func parseTemplates(funcs template.FuncMap, fsys ...fs.FS) (*template.Template, error) {
+	funcMap := FuncMap()
+	maps.Copy(funcMap, funcs)
+
+	t := template.New(MenuTemplate).Funcs(funcMap)
+	if _, ok := funcs["include"]; !ok {
+		t.Funcs(template.FuncMap{"include": func(name string, data any) (template.HTML, error) {
+			var b strings.Builder
+			if err := t.ExecuteTemplate(&b, name, data); err != nil {
+				return "", err
+			}
+			return template.HTML(b.String()), nil
+		}})
+	}
+	parsed := map[string]bool{}
+	for _, fsys := range fsys {
+		files, err := fs.Glob(fsys, "*/*.html")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			if parsed[file] {
+				continue
+			}
+			parsed[file] = true
+
+			data, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return nil, err
+			}
+			tpl := t
+			if name := "@" + file; name != t.Name() {
+				tpl = t.New(name)
+			}
+			if _, err = tpl.Parse(string(data)); err != nil {
+				return nil, fmt.Errorf("parse template %s: %w", file, err)
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// HTML executes the named template with the given data and returns the result.
+// The output is buffered, nothing is returned if the execution fails midway.
+func (t HTMLTheme) HTML(_ context.Context, name string, data any) (string, error) {
+	var b strings.Builder
+	if err := t.t.ExecuteTemplate(&b, name, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// HTMLTo executes the named template with the given data and writes the result to w as it is generated.
+func (t HTMLTheme) HTMLTo(_ context.Context, w io.Writer, name string, data any) error {
+	return t.t.ExecuteTemplate(w, name, data)
+}
+
+// templateAttributes renders the attributes of a map sorted by name, or of a *menu.Attributes in their order.
+func templateAttributes(attributes any) template.HTMLAttr {
+	switch a := attributes.(type) {
+	case map[string]any:
+		return template.HTMLAttr(htmlutil.Attributes(a))
+	case *menu.Attributes:
+		if a == nil {
+			return ""
+		}
+		return template.HTMLAttr(htmlutil.AttributesInOrder(a.Names(), a.Map()))
+	}
+	return ""
+}
+
+func dict(pairs ...any) map[string]any {
+	d := make(map[string]any, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		d[fmt.Sprintf("%v", pairs[i])] = pairs[i+1]
+	}
+	return d
+}
+
+func set(d map[string]any, key string, value any) map[string]any {
+	d[key] = value
+	return d
+}
+
+// merge copies the keys of the sources missing from dst into dst, the first value of a key wins.
+func merge(dst map[string]any, sources ...map[string]any) map[string]any {
+	for _, src := range sources {
+		for key, value := range src {
+			if _, ok := dst[key]; !ok {
+				dst[key] = value
+			}
+		}
+	}
+	return dst
+}
+
+func list(values ...any) []any {
+	return values
+}
+
+func push(l []any, values ...any) []any {
+	return append(l[:len(l):len(l)], values...)
+}
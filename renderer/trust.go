@@ -0,0 +1,40 @@
+package renderer
+
+import (
+	"context"
+	"html/template"
+
+	"github.com/gowool/menu"
+)
+
+// The trust model of the template themes: the values passed to the templates are typed, so the contextual
+// escaping of html/template applies to everything that is not explicitly trusted.
+//
+//   - Labels are passed as strings, escaped by html/template, unless the AllowSafeLabels option is set and the item
+//     has the safe_label extra: they are then passed as template.HTML, with their interpolated variables escaped.
+//   - URIs are passed as strings, whose scheme is filtered by html/template (only http, https and mailto are kept),
+//     unless the item has the trusted_uri extra or the URIPolicy option explicitly allows the URI:
+//     they are then passed as template.URL.
+
+// TemplateLabel returns the label of the item typed for html/template: a template.HTML for trusted safe labels,
+// a string escaped by html/template otherwise, see Options.Label.
+func (o *Options) TemplateLabel(ctx context.Context, item *menu.Item) any {
+	if o.AllowSafeLabels && menu.SafeLabelExtra.Get(item) {
+		return o.Label(ctx, item)
+	}
+	return item.ResolveLabel(ctx, nil)
+}
+
+// TemplateURI returns the URI of the item typed for html/template, see Options.URI: a template.URL for trusted URIs,
+// the URIs of items with the trusted_uri extra or explicitly allowed by the URIPolicy option, a string filtered
+// by html/template otherwise.
+func (o *Options) TemplateURI(item *menu.Item) any {
+	uri := o.URI(item.URI)
+	if uri == "" || uri == UnsafeURI {
+		return uri
+	}
+	if menu.TrustedURIExtra.Get(item) || o.URIPolicy != nil {
+		return template.URL(uri)
+	}
+	return uri
+}
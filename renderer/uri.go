@@ -0,0 +1,26 @@
+package renderer
+
+import (
+	"slices"
+
+	"github.com/gowool/menu"
+)
+
+// UnsafeURI replaces the URIs rejected by the RejectUnsafeURIs option, like html/template does.
+const UnsafeURI = "#ZgotmplZ"
+
+var unsafeSchemes = []string{"javascript", "vbscript", "data"}
+
+// URI returns the URI to render in a link:
+//   - an empty string when the URIPolicy option rejects the URI, so the item is rendered without a link;
+//   - UnsafeURI when the RejectUnsafeURIs option is set and the URI uses a scheme able to run code;
+//   - the URI itself otherwise.
+func (o *Options) URI(uri string) string {
+	if o.URIPolicy != nil && !o.URIPolicy.Allows(uri) {
+		return ""
+	}
+	if o.RejectUnsafeURIs && slices.Contains(unsafeSchemes, menu.URIScheme(uri)) {
+		return UnsafeURI
+	}
+	return uri
+}
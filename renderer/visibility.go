@@ -0,0 +1,94 @@
+package renderer
+
+import (
+	"context"
+	"html"
+	"html/template"
+
+	"github.com/gowool/menu"
+)
+
+// IsVisible checks if the item is displayed, visible in the Channel, accepted by the Filters option
+// and granted by the Budget option. No item is visible when the rendered item is skipped by the Hidden option.
+func (o *Options) IsVisible(ctx context.Context, item *menu.Item) bool {
+	if o.skipsRoot() {
+		return false
+	}
+	if o.granted != nil && !o.granted[item] {
+		return false
+	}
+	return item.IsVisibleIn(o.Channel) && item.IsVisible(ctx, o.Filters...)
+}
+
+// VisibleChildren returns the children of the item visible according to IsVisible, in render order, see Children.
+func (o *Options) VisibleChildren(ctx context.Context, item *menu.Item) []*menu.Item {
+	children := make([]*menu.Item, 0, len(item.Children))
+	for _, child := range o.Children(ctx, item) {
+		if o.IsVisible(ctx, child) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// HasVisibleChildren checks if at least one child of the item is visible according to IsVisible.
+func (o *Options) HasVisibleChildren(ctx context.Context, item *menu.Item) bool {
+	for _, child := range o.Children(ctx, item) {
+		if o.IsVisible(ctx, child) {
+			return true
+		}
+	}
+	return false
+}
+
+// ActsLikeFirst checks if the item is the first visible child of its parent, in render order.
+func (o *Options) ActsLikeFirst(ctx context.Context, item *menu.Item) bool {
+	if len(o.Filters) == 0 && o.Channel == "" && o.granted == nil && o.Order == OrderNone && o.Hidden == HiddenSkip && o.more == nil {
+		return item.ActsLikeFirst()
+	}
+	parent := o.renderParent(item)
+	if parent == nil || !o.IsVisible(ctx, item) {
+		return false
+	}
+	for _, child := range o.Children(ctx, parent) {
+		if o.IsVisible(ctx, child) {
+			return child == item
+		}
+	}
+	return false
+}
+
+// ActsLikeLast checks if the item is the last visible child of its parent, in render order.
+func (o *Options) ActsLikeLast(ctx context.Context, item *menu.Item) bool {
+	if len(o.Filters) == 0 && o.Channel == "" && o.granted == nil && o.Order == OrderNone && o.Hidden == HiddenSkip && o.more == nil {
+		return item.ActsLikeLast()
+	}
+	parent := o.renderParent(item)
+	if parent == nil || !o.IsVisible(ctx, item) {
+		return false
+	}
+	children := o.Children(ctx, parent)
+	for i := len(children) - 1; i >= 0; i-- {
+		if child := children[i]; o.IsVisible(ctx, child) {
+			return child == item
+		}
+	}
+	return false
+}
+
+// Label returns the label of the item resolved in the context, see menu.Item.ResolveLabel, e.g. with its
+// variables or its count. The label is escaped unless the AllowSafeLabels option is set and the item
+// has the safe_label extra, the resolved variables are escaped in any case.
+func (o *Options) Label(ctx context.Context, item *menu.Item) template.HTML {
+	if o.AllowSafeLabels && menu.SafeLabelExtra.Get(item) {
+		return template.HTML(item.ResolveLabel(ctx, html.EscapeString))
+	}
+	return template.HTML(html.EscapeString(item.ResolveLabel(ctx, nil)))
+}
+
+// Neighbors returns the items preceding and following the current item below the root in depth-first order,
+// see menu.Neighbors, skipping the items not visible in the Channel or rejected by the Filters option.
+func (o *Options) Neighbors(ctx context.Context, matcher menu.Matcher, root *menu.Item) (prev, next *menu.Item) {
+	filters := append([]menu.Filter{menu.ChannelFilter(o.Channel)}, o.Filters...)
+	return menu.Neighbors(ctx, matcher, root, filters...)
+}
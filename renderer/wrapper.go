@@ -0,0 +1,35 @@
+package renderer
+
+import (
+	"context"
+
+	"github.com/gowool/menu"
+)
+
+// ItemWrapperFunc returns the HTML of a list item, e.g. the given html surrounded by extra markup.
+// The html holds the whole <li> element of the item, its descendants included, already wrapped.
+//
+// Example usage, rendering a divider after an item:
+//
+//	func(ctx context.Context, item *menu.Item, html string) string {
+//		if item.Name != "account" {
+//			return html
+//		}
+//		return html + `<li class="divider" role="separator"></li>`
+//	}
+type ItemWrapperFunc func(ctx context.Context, item *menu.Item, html string) string
+
+// SetItemWrapper sets the function wrapping the HTML of the list items and returns a pointer to the modified
+// Options struct. A nil wrapper renders the items unchanged.
+func (o *Options) SetItemWrapper(wrapper ItemWrapperFunc) *Options {
+	o.ItemWrapper = wrapper
+	return o
+}
+
+// WithItemWrapper returns an Option wrapping the HTML of the items rendered by ListRenderer,
+// so callers can decorate specific items without writing a theme. The returned HTML is not escaped.
+func WithItemWrapper(wrapper ItemWrapperFunc) Option {
+	return func(options *Options) {
+		options.SetItemWrapper(wrapper)
+	}
+}
@@ -0,0 +1,61 @@
+package renderer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gowool/menu"
+	"github.com/gowool/menu/menutest"
+	"github.com/gowool/menu/renderer"
+)
+
+func xssRenderers(t testing.TB) map[string]renderer.Renderer {
+	t.Helper()
+
+	theme, err := renderer.NewHTMLTheme(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]renderer.Renderer{
+		"list":     renderer.NewListRenderer(menu.NewCoreMatcher(), renderer.WithRejectUnsafeURIs(true)),
+		"template": renderer.NewTemplateRenderer(theme, menu.NewCoreMatcher(), renderer.WithRejectUnsafeURIs(true)),
+	}
+}
+
+func TestRenderersHostileTree(t *testing.T) {
+	for name, r := range xssRenderers(t) {
+		t.Run(name, func(t *testing.T) {
+			output, err := r.Render(context.Background(), menutest.HostileTree())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = menutest.CheckHTML(output); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func fuzzRenderer(f *testing.F, name string) {
+	r := xssRenderers(f)[name]
+
+	menutest.AddCorpus(f)
+	f.Fuzz(func(t *testing.T, label, uri, attribute string) {
+		output, err := r.Render(context.Background(), menutest.FuzzTree(label, uri, attribute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = menutest.CheckHTML(output); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzListRenderer(f *testing.F) {
+	fuzzRenderer(f, "list")
+}
+
+func FuzzTemplateRenderer(f *testing.F) {
+	fuzzRenderer(f, "template")
+}
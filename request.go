@@ -0,0 +1,49 @@
+package menu
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ContextKey is the type of the context keys defined by the package, so they cannot collide
+// with the keys of other packages.
+type ContextKey struct {
+	name string
+}
+
+// String returns the name of the key.
+func (k ContextKey) String() string {
+	return "menu." + k.name
+}
+
+// RequestURLKey is the context key of the *url.URL of the current request, read by URLVoter.
+// Use WithRequestURL or WithHTTPRequest to set it.
+var RequestURLKey = ContextKey{name: "request_url"}
+
+// legacyURLKey is the bare string key read by URLVoter before RequestURLKey was introduced.
+//
+// Deprecated: the key will be removed in a future release, use WithRequestURL instead.
+const legacyURLKey = "url"
+
+// WithRequestURL returns a copy of the context carrying the URL of the current request.
+func WithRequestURL(ctx context.Context, u *url.URL) context.Context {
+	return context.WithValue(ctx, RequestURLKey, u)
+}
+
+// WithHTTPRequest returns a copy of the context carrying the URL of the request, see WithRequestURL.
+func WithHTTPRequest(ctx context.Context, r *http.Request) context.Context {
+	return WithRequestURL(ctx, r.URL)
+}
+
+// RequestURL returns the URL of the current request carried by the context.
+// For a release, the URL is also read from the legacy string key "url" when RequestURLKey is not set.
+func RequestURL(ctx context.Context) (*url.URL, bool) {
+	if u, ok := ctx.Value(RequestURLKey).(*url.URL); ok && u != nil {
+		return u, true
+	}
+	if u, ok := ctx.Value(legacyURLKey).(*url.URL); ok && u != nil {
+		return u, true
+	}
+	return nil, false
+}
@@ -0,0 +1,75 @@
+package menu
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+// RolesExtra holds the roles an item is restricted to, see WithRoles and RoleFilter.
+var RolesExtra = DefineExtra("menu", ExtraKey("menu", "roles"), []string(nil), "roles")
+
+// WithRoles is a function that returns an Option restricting an Item to the users holding at least one of the roles.
+func WithRoles(roles ...string) Option {
+	return RolesExtra.Option(roles)
+}
+
+type rolesKey struct{}
+
+// ContextWithRoles returns a copy of the context carrying the roles of the current user, see RoleFilter.
+func ContextWithRoles(ctx context.Context, roles ...string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, slices.Clone(roles))
+}
+
+// RolesFromContext returns the roles of the current user carried by the context, or nil.
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+// RoleFilter returns a Filter hiding the items restricted with WithRoles from the users holding none of their roles,
+// the roles of the user being carried by the context, see ContextWithRoles. The items without roles are visible to all.
+func RoleFilter() Filter {
+	return func(ctx context.Context, item *Item) bool {
+		required := RolesExtra.Get(item)
+		if len(required) == 0 {
+			return true
+		}
+		for _, role := range RolesFromContext(ctx) {
+			if slices.Contains(required, role) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Roles returns the sorted roles the item and its descendants are restricted to, see WithRoles.
+func (i *Item) Roles() []string {
+	var roles []string
+	for item := range i.All() {
+		roles = append(roles, RolesExtra.Get(item)...)
+	}
+	slices.Sort(roles)
+	return slices.Compact(roles)
+}
+
+// RoleSetHash returns a hash of the roles of the current user carried by the context, see ContextWithRoles,
+// that does not depend on their order nor on their duplicates, e.g. to cache the menus rendered per role set
+// instead of per user. With roles, only the roles among them are hashed, so the users whose roles differ
+// only by roles the menu does not check share the hash, see Item.Roles.
+func RoleSetHash(ctx context.Context, roles ...string) string {
+	user := slices.Clone(RolesFromContext(ctx))
+	if len(roles) > 0 {
+		user = slices.DeleteFunc(user, func(role string) bool {
+			return !slices.Contains(roles, role)
+		})
+	}
+	slices.Sort(user)
+	user = slices.Compact(user)
+
+	sum := sha256.Sum256([]byte(strings.Join(user, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
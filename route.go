@@ -0,0 +1,65 @@
+package menu
+
+import (
+	"context"
+	"slices"
+)
+
+var _ Voter = RouteNameVoter{}
+
+// RouteParamsExtra holds the route parameters an item requires to be current, see RouteNameVoter.
+var RouteParamsExtra = DefineExtra("menu", ExtraKey("menu", "route_params"), map[string]string(nil), "route_params")
+
+// WithRouteParams is a function that returns an Option for setting the "menu.route_params" extra of an Item.
+func WithRouteParams(params map[string]string) Option {
+	return RouteParamsExtra.Option(params)
+}
+
+// Route is the named route of the current request, as resolved by the router of the application.
+type Route struct {
+	Name   string
+	Params map[string]string
+}
+
+// RouteKey is the context key of the Route of the current request, read by RouteNameVoter.
+var RouteKey = ContextKey{name: "route"}
+
+// WithRoute returns a copy of the context carrying the named route of the current request and its parameters.
+func WithRoute(ctx context.Context, name string, params map[string]string) context.Context {
+	return context.WithValue(ctx, RouteKey, Route{Name: name, Params: params})
+}
+
+// RouteFromContext returns the route of the current request carried by the context.
+func RouteFromContext(ctx context.Context) (Route, bool) {
+	route, ok := ctx.Value(RouteKey).(Route)
+	return route, ok
+}
+
+// RouteNameVoter is a Voter matching items by route name instead of URI, so current detection keeps working
+// when URIs change or hold parameters. An item is current when the name of the route carried by the context,
+// see WithRoute, is one of its routes, see WithRoutes, and the route has all the parameters required by the item
+// with the same values, see WithRouteParams.
+//
+// The voter abstains for items without routes and when the context does not carry a route.
+type RouteNameVoter struct{}
+
+// MatchItem checks whether the route carried by the context matches the routes of the item.
+func (v RouteNameVoter) MatchItem(ctx context.Context, item *Item) *bool {
+	routes := RoutesExtra.Get(item)
+	if len(routes) == 0 {
+		return nil
+	}
+
+	route, ok := RouteFromContext(ctx)
+	if !ok || !slices.Contains(routes, route.Name) {
+		return nil
+	}
+
+	for name, value := range RouteParamsExtra.Get(item) {
+		if param, ok := route.Params[name]; !ok || param != value {
+			return nil
+		}
+	}
+
+	return &ok
+}
@@ -0,0 +1,86 @@
+package menu
+
+import (
+	"context"
+	"sync"
+)
+
+var _ CurrentItemsMatcher = (*ScopedMatcher)(nil)
+
+type scopeKey struct{}
+
+type matcherScope struct {
+	mu    sync.Mutex
+	cache map[scopedEntry]bool
+}
+
+type scopedEntry struct {
+	matcher *ScopedMatcher
+	item    *Item
+}
+
+// WithMatcherScope returns a copy of the context carrying a new cache for the ScopedMatcher instances,
+// typically created once per request by a middleware.
+func WithMatcherScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &matcherScope{cache: map[scopedEntry]bool{}})
+}
+
+// ScopedMatcher is a Matcher keeping its cache in the context instead of in the matcher itself, see WithMatcherScope.
+// Unlike CoreMatcher, a single ScopedMatcher can be shared by concurrent requests of a long-lived server
+// without clearing it: the current state of a request never leaks into another one.
+// Without a scope in the context, the voters are consulted on every call.
+type ScopedMatcher struct {
+	voters []Voter
+}
+
+// NewScopedMatcher creates a new ScopedMatcher with the given voters.
+func NewScopedMatcher(voters ...Voter) *ScopedMatcher {
+	return &ScopedMatcher{voters: voters}
+}
+
+// IsCurrent checks whether an item is current, like CoreMatcher.IsCurrent, caching the result in the scope of the context.
+func (m *ScopedMatcher) IsCurrent(ctx context.Context, item *Item) bool {
+	if item.Current != nil {
+		return *item.Current
+	}
+
+	scope, _ := ctx.Value(scopeKey{}).(*matcherScope)
+	entry := scopedEntry{matcher: m, item: item}
+	if scope != nil {
+		scope.mu.Lock()
+		current, ok := scope.cache[entry]
+		scope.mu.Unlock()
+		if ok {
+			return current
+		}
+	}
+
+	var current bool
+	for _, voter := range m.voters {
+		if v := voter.MatchItem(ctx, item); v != nil {
+			current = *v
+			break
+		}
+	}
+
+	if scope != nil {
+		scope.mu.Lock()
+		scope.cache[entry] = current
+		scope.mu.Unlock()
+	}
+	return current
+}
+
+// IsAncestor checks whether the item is an ancestor of a current item, up to the given depth.
+// The depth is not modified.
+func (m *ScopedMatcher) IsAncestor(ctx context.Context, item *Item, depth *int) bool {
+	return isAncestor(ctx, m, item, depth)
+}
+
+// CurrentItems returns the items below the root that are current, in depth-first order, or nil if none is.
+func (m *ScopedMatcher) CurrentItems(ctx context.Context, root *Item) []*Item {
+	return currentItems(ctx, m, root)
+}
+
+// Clear is a no-op: the cache belongs to the scope of the context and is released with it.
+func (m *ScopedMatcher) Clear() {}
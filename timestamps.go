@@ -0,0 +1,94 @@
+package menu
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Lifecycle extras of the items, optional and maintained by the code editing the menus, see Item.Touch.
+var (
+	// CreatedAtExtra holds the time the item was created.
+	CreatedAtExtra = DefineExtra("menu", ExtraKey("menu", "created_at"), time.Time{}, "created_at")
+
+	// UpdatedAtExtra holds the time the item was last changed.
+	UpdatedAtExtra = DefineExtra("menu", ExtraKey("menu", "updated_at"), time.Time{}, "updated_at")
+
+	// BadgeExtra holds a short text rendered after the label of the item, e.g. "New", see NewBadgeDecorator.
+	BadgeExtra = DefineExtra("menu", ExtraKey("menu", "badge"), "", "badge")
+)
+
+// WithCreatedAt is a function that returns an Option setting the CreatedAtExtra of an Item.
+func WithCreatedAt(t time.Time) Option {
+	return CreatedAtExtra.Option(t)
+}
+
+// WithUpdatedAt is a function that returns an Option setting the UpdatedAtExtra of an Item.
+func WithUpdatedAt(t time.Time) Option {
+	return UpdatedAtExtra.Option(t)
+}
+
+// WithBadge is a function that returns an Option setting the BadgeExtra of an Item.
+func WithBadge(badge string) Option {
+	return BadgeExtra.Option(badge)
+}
+
+// CreatedAt returns the time the item was created, or the zero time.
+func (i *Item) CreatedAt() time.Time {
+	return CreatedAtExtra.Get(i)
+}
+
+// UpdatedAt returns the time the item was last changed, falling back to the time it was created, or the zero time.
+func (i *Item) UpdatedAt() time.Time {
+	if t := UpdatedAtExtra.Get(i); !t.IsZero() {
+		return t
+	}
+	return i.CreatedAt()
+}
+
+// Touch records a change of the item at the given time: it sets the UpdatedAtExtra,
+// and the CreatedAtExtra if the item has none. It returns the item.
+func (i *Item) Touch(now time.Time) *Item {
+	if CreatedAtExtra.Get(i).IsZero() {
+		CreatedAtExtra.Set(i, now)
+	}
+	UpdatedAtExtra.Set(i, now)
+	return i
+}
+
+// SortChildrenByUpdated sorts the children of the item by UpdatedAt, the most recently changed first.
+// The children without timestamp come last, the order of the children changed at the same time is kept,
+// see ReorderChildren to restore the order of their positions.
+func (i *Item) SortChildrenByUpdated() {
+	slices.SortStableFunc(i.Children, func(a, b *Item) int {
+		ta, tb := a.UpdatedAt(), b.UpdatedAt()
+		switch {
+		case ta.IsZero() && tb.IsZero():
+			return 0
+		case ta.IsZero():
+			return 1
+		case tb.IsZero():
+			return -1
+		}
+		return tb.Compare(ta)
+	})
+}
+
+// NewBadgeDecorator returns a decorator for DecorateStage setting the BadgeExtra of the items changed within
+// the window to the label, e.g. "New" for the items changed within the last week, see Item.UpdatedAt.
+// The badge of the other items is left untouched. The now function returns the current time, time.Now if nil.
+//
+// Example usage:
+//
+//	provider.Use(menu.DecorateStage(menu.NewBadgeDecorator("New", 7*24*time.Hour, nil)))
+func NewBadgeDecorator(label string, window time.Duration, now func() time.Time) func(ctx context.Context, item *Item) error {
+	if now == nil {
+		now = time.Now
+	}
+	return func(_ context.Context, item *Item) error {
+		if t := item.UpdatedAt(); !t.IsZero() && now().Sub(t) <= window {
+			BadgeExtra.Set(item, label)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package menu
+
+import "strings"
+
+// URIPatternExtra holds the URI patterns of an item, matched by URLVoter and LocaleVoter in addition to its URI.
+var URIPatternExtra = DefineExtra("menu", ExtraKey("menu", "uri_pattern"), []string(nil), "uri_pattern")
+
+// uriPattern is a compiled URI pattern, see MatchURIPattern.
+type uriPattern struct {
+	value    string
+	wildcard bool
+	// section is the path matched by a pattern ending with "/*" besides the paths below it.
+	section    string
+	hasSection bool
+}
+
+// compiledURIPatterns holds the compiled patterns of the URIPatternExtra of an item, along with the slice they
+// were compiled from, so a value of the extra replaced without SetURIPatterns is detected and compiled on the fly.
+type compiledURIPatterns struct {
+	source   []string
+	patterns []uriPattern
+}
+
+func compileURIPattern(pattern string) uriPattern {
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	if !ok {
+		return uriPattern{value: pattern}
+	}
+	section, hasSection := strings.CutSuffix(prefix, "/")
+	return uriPattern{value: prefix, wildcard: true, section: section, hasSection: hasSection}
+}
+
+func (p uriPattern) match(path string) bool {
+	if !p.wildcard {
+		return p.value == path
+	}
+	if p.hasSection && path == p.section {
+		return true
+	}
+	return strings.HasPrefix(path, p.value)
+}
+
+// WithURIPattern is a function that returns an Option for setting the "menu.uri_pattern" extra of an Item,
+// making the item current for the matching request paths while its URI, rendered as href, is left untouched:
+//
+//	menu.NewItem("docs", menu.WithURI("/docs"), menu.WithURIPattern("/docs/*"))
+//
+// A pattern ending with "/*" matches the path before the wildcard and all the paths below it,
+// other patterns ending with "*" match the paths starting with the pattern, the others match the exact path.
+func WithURIPattern(patterns ...string) Option {
+	return func(item *Item) error {
+		item.SetURIPatterns(patterns...)
+		return nil
+	}
+}
+
+// SetURIPatterns sets the URI patterns of the item, see WithURIPattern, and compiles them once for the voters.
+func (i *Item) SetURIPatterns(patterns ...string) {
+	URIPatternExtra.Set(i, patterns)
+	i.compileURIPatterns()
+}
+
+// compileURIPatterns compiles the URI patterns of the item, e.g. after its extras are decoded.
+func (i *Item) compileURIPatterns() {
+	source, ok := URIPatternExtra.Lookup(i)
+	if !ok || len(source) == 0 {
+		i.uriPatterns = nil
+		return
+	}
+
+	compiled := &compiledURIPatterns{source: source, patterns: make([]uriPattern, len(source))}
+	for n, pattern := range source {
+		compiled.patterns[n] = compileURIPattern(pattern)
+	}
+	i.uriPatterns = compiled
+}
+
+// MatchesURIPattern checks if the path matches one of the URI patterns of the item, see WithURIPattern.
+// The patterns compiled when the extra was set or decoded are used, the others are compiled on the fly.
+func (i *Item) MatchesURIPattern(path string) bool {
+	source := URIPatternExtra.Get(i)
+	if len(source) == 0 {
+		return false
+	}
+
+	if c := i.uriPatterns; c != nil && len(c.source) == len(source) && &c.source[0] == &source[0] {
+		for _, pattern := range c.patterns {
+			if pattern.match(path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, pattern := range source {
+		if MatchURIPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchURIPattern checks if the path matches the pattern, see WithURIPattern.
+func MatchURIPattern(pattern, path string) bool {
+	return compileURIPattern(pattern).match(path)
+}
@@ -0,0 +1,115 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrURINotAllowed is returned when a URI is rejected by a URIPolicy.
+var ErrURINotAllowed = errors.New("menu item uri not allowed")
+
+// SafeSchemes are the schemes allowed by DefaultURIPolicy.
+var SafeSchemes = []string{"http", "https", "mailto", "tel"}
+
+// DefaultURIPolicy allows relative URIs and the absolute URIs using one of the SafeSchemes.
+var DefaultURIPolicy = URIPolicy{Schemes: SafeSchemes}
+
+// URIPolicy restricts the URIs of the items, protecting applications building menus from user-generated content
+// against javascript: or data: URIs. Relative URIs, e.g. "/blog" or "?page=2", are always allowed, the absolute
+// URIs must use one of the Schemes, unless RelativeOnly is set. Scheme-relative URIs, e.g. "//example.com",
+// are considered absolute URIs using the http scheme.
+type URIPolicy struct {
+	// Schemes are the allowed schemes of absolute URIs, compared case-insensitively.
+	Schemes []string `json:"schemes,omitempty"`
+	// RelativeOnly rejects all the absolute URIs.
+	RelativeOnly bool `json:"relative_only,omitempty"`
+}
+
+// Allows checks if the policy allows the URI. The empty URI is always allowed.
+func (p URIPolicy) Allows(uri string) bool {
+	return p.Check(uri) == nil
+}
+
+// Check returns an error wrapping ErrURINotAllowed if the policy rejects the URI.
+func (p URIPolicy) Check(uri string) error {
+	scheme := URIScheme(uri)
+	if scheme == "" && strings.HasPrefix(strings.TrimLeft(uri, " "), "//") {
+		scheme = "http"
+	}
+	if scheme == "" {
+		return nil
+	}
+
+	if p.RelativeOnly {
+		return fmt.Errorf("%w: %q is not relative", ErrURINotAllowed, uri)
+	}
+	if !slices.ContainsFunc(p.Schemes, func(allowed string) bool { return strings.EqualFold(allowed, scheme) }) {
+		return fmt.Errorf("%w: scheme %q of %q", ErrURINotAllowed, scheme, uri)
+	}
+	return nil
+}
+
+// URIScheme returns the lower-cased scheme of the URI as browsers read it, or an empty string for relative URIs:
+// leading spaces and control characters are ignored, as are tabs and newlines within the scheme,
+// e.g. the scheme of " java\tscript:alert(1)" is "javascript".
+func URIScheme(uri string) string {
+	uri = strings.TrimLeftFunc(uri, func(r rune) bool { return r <= ' ' })
+
+	end := strings.IndexAny(uri, ":/?#")
+	if end <= 0 || uri[end] != ':' {
+		return ""
+	}
+
+	scheme := strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, uri[:end])
+
+	for i, c := range scheme {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case i > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return ""
+		}
+	}
+	return strings.ToLower(scheme)
+}
+
+// WithCheckedURI is a function that returns an Option setting the URI of an Item after checking it against the policy.
+// The option fails with an error wrapping ErrURINotAllowed if the policy rejects the URI.
+func WithCheckedURI(uri string, policy URIPolicy) Option {
+	return func(item *Item) error {
+		if err := policy.Check(uri); err != nil {
+			return err
+		}
+		item.URI = uri
+		return nil
+	}
+}
+
+// URIPolicyStage returns a Stage checking the URIs of the items of the menu against the policy.
+// The rejected URIs are removed when omit is set, leaving the items without a link,
+// otherwise the stage fails with the errors of all the rejected URIs.
+func URIPolicyStage(policy URIPolicy, omit bool) Stage {
+	return func(_ context.Context, root *Item) error {
+		var errs []error
+		for item := range root.All() {
+			err := policy.Check(item.URI)
+			if err == nil {
+				continue
+			}
+			if omit {
+				item.URI = ""
+				continue
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", item.PathString("/"), err))
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,112 @@
+// Package yamlmenu provides a menu.Loader building menus from YAML documents.
+// It lives in its own package so the YAML dependency is only pulled by the projects using it.
+package yamlmenu
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gowool/menu"
+)
+
+var _ menu.Loader = Loader{}
+
+// Loader is a menu.Loader building menus from YAML documents describing a nested menu.ItemConfig.
+// The keys are the JSON names of the configuration, e.g.
+//
+//	name: main
+//	children:
+//	  - name: home
+//	    label: Home
+//	    uri: /
+//	  - name: blog
+//	    label: Blog
+//	    uri: /blog
+//	    position: 10
+//	    attributes:
+//	      class: blog
+//	    extras:
+//	      routes: [blog_index, blog_article]
+//	    children: [...]
+//
+// The data can be a []byte, a string or an io.Reader. Children keep the order of the document.
+type Loader struct{}
+
+// NewLoader returns a new instance of Loader.
+func NewLoader() Loader {
+	return Loader{}
+}
+
+// Load decodes the YAML document and builds the menu it describes.
+// Malformed documents and children without a name are reported with menu.ErrInvalidConfig.
+func (l Loader) Load(ctx context.Context, data any) (*menu.Item, error) {
+	var r io.Reader
+	switch data := data.(type) {
+	case []byte:
+		r = bytes.NewReader(data)
+	case string:
+		r = bytes.NewReader([]byte(data))
+	case io.Reader:
+		r = data
+	default:
+		return nil, fmt.Errorf("%w: expected []byte, string or io.Reader, got %T", menu.ErrUnsupported, data)
+	}
+
+	var doc any
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", menu.ErrInvalidConfig, err)
+	}
+
+	// the YAML document is converted to JSON so it shares the field names and the validation of menu.JSONLoader
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", menu.ErrInvalidConfig, err)
+	}
+
+	return menu.NewJSONLoader().Load(ctx, json.RawMessage(raw))
+}
+
+// Supports checks if the given data is a []byte, a string or a *bufio.Reader holding a YAML document
+// other than a JSON object, which is left to menu.JSONLoader whatever the order of the loaders of a menu.ChainLoader.
+// Other readers cannot be inspected without consuming them and are not claimed, but Load accepts them,
+// and menu.ChainLoader.Load buffers them for Supports.
+func (l Loader) Supports(data any) bool {
+	switch data := data.(type) {
+	case []byte:
+		return !isJSONObject(data)
+	case string:
+		return !isJSONObject([]byte(data))
+	case *bufio.Reader:
+		return !peekJSONObject(data)
+	default:
+		return false
+	}
+}
+
+// isJSONObject checks if the data is a valid JSON object, as opposed to a YAML flow mapping such as {name: main}.
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '{' && json.Valid(data)
+}
+
+// peekJSONObject checks if the first non-space character of the reader opens a mapping, without consuming it.
+// Unlike isJSONObject, the document cannot be validated, so a YAML flow mapping is left to menu.JSONLoader too.
+func peekJSONObject(r *bufio.Reader) bool {
+	for n := 1; n <= r.Size(); n++ {
+		data, _ := r.Peek(n)
+		if len(data) < n {
+			return false
+		}
+		if c := data[n-1]; c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return c == '{'
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package yamlmenu
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gowool/menu"
+)
+
+const (
+	jsonMenu = `{"name":"json","children":[{"name":"home","uri":"/"}]}`
+	yamlMenu = "name: yaml\nchildren:\n  - name: home\n    uri: /\n"
+)
+
+func TestChainLoader(t *testing.T) {
+	chains := map[string]menu.ChainLoader{
+		"json first": menu.NewChainLoader(menu.NewJSONLoader(), NewLoader()),
+		"yaml first": menu.NewChainLoader(NewLoader(), menu.NewJSONLoader()),
+	}
+	inputs := map[string]func(string) any{
+		"bytes":  func(s string) any { return []byte(s) },
+		"string": func(s string) any { return s },
+		"reader": func(s string) any { return strings.NewReader(s) },
+	}
+
+	for chainName, chain := range chains {
+		for inputName, input := range inputs {
+			for _, doc := range []string{jsonMenu, yamlMenu} {
+				root, err := chain.Load(context.Background(), input(doc))
+				if err != nil {
+					t.Fatalf("%s, %s: Load() error = %v", chainName, inputName, err)
+				}
+				want := "yaml"
+				if doc == jsonMenu {
+					want = "json"
+				}
+				if root.Name != want || root.Child("home") == nil {
+					t.Errorf("%s, %s: Load() = %q, want %q with home", chainName, inputName, root.Name, want)
+				}
+			}
+		}
+	}
+}
+
+func TestLoaderSupports(t *testing.T) {
+	tests := []struct {
+		data any
+		want bool
+	}{
+		{yamlMenu, true},
+		{[]byte(yamlMenu), true},
+		{"{name: main}", true},
+		{jsonMenu, false},
+		{[]byte(" " + jsonMenu), false},
+		{strings.NewReader(yamlMenu), false},
+		{42, false},
+	}
+	for _, tt := range tests {
+		if got := NewLoader().Supports(tt.data); got != tt.want {
+			t.Errorf("Supports(%#v) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestLoaderFieldMapping(t *testing.T) {
+	doc := `
+name: main
+id: main-id
+children:
+  - name: home
+    label: Home
+    uri: /
+    position: 20
+  - name: blog
+    label: Blog
+    uri: /blog
+    position: 10
+    display_children: false
+    attributes:
+      class: blog
+    link_attributes:
+      rel: nofollow
+    children_attributes:
+      class: submenu
+    label_attributes:
+      lang: en
+    extras:
+      routes: [blog_index, blog_article]
+    children:
+      - name: draft
+        display: false
+`
+	root, err := NewLoader().Load(context.Background(), doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if root.Name != "main" || root.ID != "main-id" || root.ByID("main-id") != root {
+		t.Errorf("root = %q with ID %q, want main with main-id", root.Name, root.ID)
+	}
+	if len(root.Children) != 2 || root.Children[0].Name != "home" || root.Children[1].Name != "blog" {
+		t.Fatalf("the children do not keep the order of the document: %v", root.Children)
+	}
+
+	home := root.Child("home")
+	if home.Label != "Home" || home.URI != "/" || home.Position != 20 || !home.Display || !home.DisplayChildren {
+		t.Errorf("home = %+v", home)
+	}
+
+	blog := root.Child("blog")
+	if blog.Label != "Blog" || blog.URI != "/blog" || blog.Position != 10 || blog.DisplayChildren {
+		t.Errorf("blog = %+v", blog)
+	}
+	if blog.Attributes["class"] != "blog" || blog.LinkAttributes["rel"] != "nofollow" ||
+		blog.ChildrenAttributes["class"] != "submenu" || blog.LabelAttributes["lang"] != "en" {
+		t.Errorf("the attributes of blog are not mapped: %+v", blog)
+	}
+	if routes := menu.RoutesExtra.Get(blog); len(routes) != 2 || routes[0] != "blog_index" || routes[1] != "blog_article" {
+		t.Errorf("RoutesExtra = %v, want [blog_index blog_article]", routes)
+	}
+
+	draft := blog.Child("draft")
+	if draft == nil || draft.Display || draft.Parent != blog {
+		t.Errorf("draft = %+v, want a hidden child of blog", draft)
+	}
+}
+
+func TestLoaderInvalidConfig(t *testing.T) {
+	for _, doc := range []string{"name: [main", "name: main\nchildren:\n  - label: Nameless\n"} {
+		if _, err := NewLoader().Load(context.Background(), doc); !errors.Is(err, menu.ErrInvalidConfig) {
+			t.Errorf("Load(%q) error = %v, want ErrInvalidConfig", doc, err)
+		}
+	}
+}